@@ -0,0 +1,52 @@
+// Package api defines the abstraction shared by all code host clients.
+package api
+
+import (
+	"context"
+
+	"github.com/sushant-115/pr-effort-estimator/api/azuredevops"
+	"github.com/sushant-115/pr-effort-estimator/api/bitbucket"
+	"github.com/sushant-115/pr-effort-estimator/api/gerrit"
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlab"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlocal"
+)
+
+// PullRequestSource is implemented by every code host client that can
+// produce pull request data for the metrics package.
+type PullRequestSource interface {
+	// GetPullRequests fetches pull requests filtered by state ("open", "closed", "all"),
+	// perPage at a time, or github.DefaultPerPage when perPage isn't positive.
+	GetPullRequests(ctx context.Context, state string, perPage int) ([]*github.PrData, error)
+}
+
+// FileSource is implemented by clients that can read files of the repository, e.g. CODEOWNERS.
+type FileSource interface {
+	// GetFile reads a file at path as of ref (a commit SHA or branch), or from the default branch when ref is empty.
+	GetFile(ctx context.Context, path, ref string) ([]byte, error)
+}
+
+// DirectorySource is implemented by clients that can list directories of the repository.
+type DirectorySource interface {
+	// ListDirectory returns the names of the entries of dir as of ref; "" or "." is the root.
+	// A directory that doesn't exist there yields an error wrapping github.ErrFileNotFound.
+	ListDirectory(ctx context.Context, dir, ref string) ([]string, error)
+}
+
+// Compile-time checks that every client satisfies the interface.
+var (
+	_ PullRequestSource = (*github.Client)(nil)
+	_ PullRequestSource = (*gitlab.Client)(nil)
+	_ PullRequestSource = (*bitbucket.Client)(nil)
+	_ PullRequestSource = (*azuredevops.Client)(nil)
+	_ PullRequestSource = (*gerrit.Client)(nil)
+	_ PullRequestSource = (*gitlocal.Client)(nil)
+
+	_ FileSource = (*github.Client)(nil)
+	_ FileSource = (*gitlab.Client)(nil)
+	_ FileSource = (*gitlocal.Client)(nil)
+
+	_ DirectorySource = (*github.Client)(nil)
+	_ DirectorySource = (*gitlab.Client)(nil)
+	_ DirectorySource = (*gitlocal.Client)(nil)
+)
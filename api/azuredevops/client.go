@@ -0,0 +1,233 @@
+package azuredevops
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+const apiVersion = "7.1"
+
+type Client struct {
+	httpClient *http.Client
+	config     *config.AzureDevOpsConfig
+}
+
+func NewClient(cfg *config.AzureDevOpsConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		config:     cfg,
+	}
+}
+
+// GetPullRequests fetches pull requests for the configured Azure Repos repository.
+// The state follows the GitHub vocabulary ("open", "closed", "all"); "closed" includes completed PRs.
+// Azure DevOps does not report line counts, so only ChangedFiles is populated among the size fields.
+func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int) ([]*github.PrData, error) {
+	if perPage <= 0 {
+		perPage = github.DefaultPerPage
+	}
+	var allPrs []*github.PrData
+	for _, status := range azureStatuses(state) {
+		for skip := 0; ; skip += perPage {
+			query := url.Values{}
+			query.Set("searchCriteria.status", status)
+			query.Set("$top", strconv.Itoa(perPage))
+			query.Set("$skip", strconv.Itoa(skip))
+
+			var prs list[pullRequest]
+			if err := c.get(ctx, "pullrequests", query, &prs); err != nil {
+				return nil, err
+			}
+			for _, pr := range prs.Value {
+				allPrs = append(allPrs, c.toPrData(ctx, pr))
+			}
+			if len(prs.Value) < perPage {
+				break
+			}
+		}
+	}
+	return allPrs, nil
+}
+
+// toPrData enriches a pull request with reviewer votes, comment threads and changed files.
+func (c *Client) toPrData(ctx context.Context, pr pullRequest) *github.PrData {
+	prData := &github.PrData{
+		Number:    pr.PullRequestID,
+		Title:     pr.Title,
+		State:     "closed",
+		Author:    pr.CreatedBy.UniqueName,
+		CreatedAt: pr.CreationDate,
+		ClosedAt:  pr.ClosedDate,
+	}
+	switch pr.Status {
+	case "active":
+		prData.State = "open"
+	case "completed":
+		prData.State = "merged"
+		prData.MergedAt = pr.ClosedDate
+	}
+	for _, label := range pr.Labels {
+		prData.Labels = append(prData.Labels, label.Name)
+	}
+
+	var threads list[thread]
+	if err := c.get(ctx, fmt.Sprintf("pullRequests/%d/threads", pr.PullRequestID), nil, &threads); err != nil {
+		log.Printf("Warning: Could not fetch threads for PR #%d: %v", pr.PullRequestID, err)
+	}
+	prData.Reviews = reviewsFromThreads(threads.Value, prData.Author)
+
+	for _, r := range pr.Reviewers {
+		if r.IsContainer {
+			prData.RequestedReviewers = append(prData.RequestedReviewers, "@"+r.DisplayName)
+		} else {
+			prData.RequestedReviewers = append(prData.RequestedReviewers, r.UniqueName)
+		}
+
+		state := voteState(r.Vote)
+		if state == "" || hasReview(prData.Reviews, r.UniqueName, state) {
+			continue
+		}
+		// Current votes carry no timestamp, so they can't contribute to FirstReviewedAt.
+		prData.Reviews = append(prData.Reviews, github.Review{Reviewer: r.UniqueName, State: state})
+	}
+	prData.FirstReviewedAt = github.EarliestReview(prData.Reviews)
+
+	files, err := c.changedFiles(ctx, pr.PullRequestID)
+	if err != nil {
+		log.Printf("Warning: Could not fetch changes for PR #%d: %v", pr.PullRequestID, err)
+	}
+	prData.ChangedFiles = files
+	return prData
+}
+
+// changedFiles counts the files changed in the latest iteration of a pull request.
+func (c *Client) changedFiles(ctx context.Context, id int) (int, error) {
+	var iterations list[iteration]
+	if err := c.get(ctx, fmt.Sprintf("pullRequests/%d/iterations", id), nil, &iterations); err != nil {
+		return 0, err
+	}
+	if len(iterations.Value) == 0 {
+		return 0, nil
+	}
+	latest := iterations.Value[len(iterations.Value)-1].ID
+
+	files := 0
+	query := url.Values{"$top": {"2000"}}
+	for {
+		var changes iterationChanges
+		if err := c.get(ctx, fmt.Sprintf("pullRequests/%d/iterations/%d/changes", id, latest), query, &changes); err != nil {
+			return files, err
+		}
+		files += len(changes.ChangeEntries)
+		if changes.NextSkip == 0 {
+			return files, nil
+		}
+		query.Set("$skip", strconv.Itoa(changes.NextSkip))
+	}
+}
+
+// get performs an authenticated GET against the repository's Git API and decodes the JSON body into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api-version", apiVersion)
+	u := fmt.Sprintf("%s/%s/_apis/git/repositories/%s/%s?%s", strings.TrimRight(c.config.OrgURL, "/"),
+		url.PathEscape(c.config.Project), url.PathEscape(c.config.Repo), path, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	req.SetBasicAuth("", c.config.Token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// azureStatuses translates a GitHub-style state filter into Azure DevOps search statuses.
+func azureStatuses(state string) []string {
+	switch state {
+	case "open":
+		return []string{"active"}
+	case "closed":
+		return []string{"completed", "abandoned"}
+	default:
+		return []string{"all"}
+	}
+}
+
+// voteState maps an Azure DevOps reviewer vote onto the GitHub review state vocabulary.
+func voteState(vote int) string {
+	switch {
+	case vote > 0:
+		return "APPROVED"
+	case vote < 0:
+		return "CHANGES_REQUESTED"
+	default:
+		return ""
+	}
+}
+
+// reviewsFromThreads extracts vote updates and comments by anyone but the author.
+func reviewsFromThreads(threads []thread, author string) []github.Review {
+	var reviews []github.Review
+	for _, t := range threads {
+		if len(t.Comments) == 0 {
+			continue
+		}
+		if fmt.Sprint(t.Properties["CodeReviewThreadType"].Value) == "VoteUpdate" {
+			vote, err := strconv.Atoi(fmt.Sprint(t.Properties["CodeReviewVoteResult"].Value))
+			if state := voteState(vote); err == nil && state != "" {
+				reviews = append(reviews, github.Review{
+					Reviewer:    t.Comments[0].Author.UniqueName,
+					State:       state,
+					SubmittedAt: t.PublishedDate,
+				})
+			}
+			continue
+		}
+		for _, comment := range t.Comments {
+			if comment.CommentType != "text" || comment.Author.UniqueName == author {
+				continue
+			}
+			reviews = append(reviews, github.Review{
+				Reviewer:    comment.Author.UniqueName,
+				State:       "COMMENTED",
+				SubmittedAt: comment.PublishedDate,
+			})
+		}
+	}
+	return reviews
+}
+
+func hasReview(reviews []github.Review, reviewer, state string) bool {
+	for _, review := range reviews {
+		if review.Reviewer == reviewer && review.State == state {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package azuredevops_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/azuredevops"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// setupMockAzureDevOpsServer creates a stand-in for the Azure Repos REST API with one completed PR.
+func setupMockAzureDevOpsServer(t *testing.T, created time.Time) *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	const prefix = "/org/proj/_apis/git/repositories/repo/"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(prefix+"pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		if _, token, ok := r.BasicAuth(); !ok || token != "dummy_token" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if r.URL.Query().Get("searchCriteria.status") != "completed" {
+			writeJSON(w, map[string]any{"value": []any{}, "count": 0})
+			return
+		}
+		writeJSON(w, map[string]any{"count": 1, "value": []map[string]any{{
+			"pullRequestId": 12,
+			"title":         "Tune retries",
+			"status":        "completed",
+			"createdBy":     map[string]any{"uniqueName": "alice@example.com"},
+			"creationDate":  created,
+			"closedDate":    created.Add(20 * time.Hour),
+			"labels":        []map[string]any{{"name": "infra"}},
+			"reviewers": []map[string]any{
+				{"uniqueName": "bob@example.com", "vote": 10},
+				{"uniqueName": "dave@example.com", "vote": -5},
+				{"uniqueName": "vstfs:///Classification/TeamProject/1", "displayName": "[proj]\\Infra Team", "isContainer": true},
+			},
+		}}})
+	})
+	mux.HandleFunc(prefix+"pullRequests/12/threads", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"value": []map[string]any{
+			{
+				"publishedDate": created.Add(8 * time.Hour),
+				"comments":      []map[string]any{{"author": map[string]any{"uniqueName": "bob@example.com"}, "commentType": "system"}},
+				"properties": map[string]any{
+					"CodeReviewThreadType":   map[string]any{"$type": "System.String", "$value": "VoteUpdate"},
+					"CodeReviewVoteResult":   map[string]any{"$type": "System.String", "$value": "10"},
+					"CodeReviewVotedByCount": map[string]any{"$type": "System.Int32", "$value": 1},
+				},
+			},
+			{
+				"publishedDate": created.Add(2 * time.Hour),
+				"comments": []map[string]any{
+					{"author": map[string]any{"uniqueName": "alice@example.com"}, "publishedDate": created.Add(time.Hour), "commentType": "text"},
+					{"author": map[string]any{"uniqueName": "carol@example.com"}, "publishedDate": created.Add(2 * time.Hour), "commentType": "text"},
+				},
+			},
+		}})
+	})
+	mux.HandleFunc(prefix+"pullRequests/12/iterations", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"value": []map[string]any{{"id": 1}, {"id": 2}}})
+	})
+	mux.HandleFunc(prefix+"pullRequests/12/iterations/2/changes", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"changeEntries": []map[string]any{
+			{"changeType": "edit", "item": map[string]any{"path": "/retry.go"}},
+			{"changeType": "add", "item": map[string]any{"path": "/retry_test.go"}},
+			{"changeType": "edit", "item": map[string]any{"path": "/README.md"}},
+		}})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPullRequests(t *testing.T) {
+	created := time.Date(2025, 5, 6, 10, 0, 0, 0, time.UTC)
+	server := setupMockAzureDevOpsServer(t, created)
+
+	client := azuredevops.NewClient(&config.AzureDevOpsConfig{
+		Token:   "dummy_token",
+		OrgURL:  server.URL + "/org",
+		Project: "proj",
+		Repo:    "repo",
+	})
+
+	prs, err := client.GetPullRequests(context.Background(), "closed", 50)
+	if err != nil {
+		t.Fatalf("GetPullRequests failed: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("Expected 1 pull request, got %d", len(prs))
+	}
+
+	pr := prs[0]
+	if pr.Number != 12 || pr.State != "merged" || pr.Author != "alice@example.com" {
+		t.Errorf("Unexpected PR identity: %+v", pr)
+	}
+	if pr.MergedAt == nil || !pr.MergedAt.Equal(created.Add(20*time.Hour)) {
+		t.Errorf("Expected MergedAt %v, got %v", created.Add(20*time.Hour), pr.MergedAt)
+	}
+	if pr.FirstReviewedAt == nil || !pr.FirstReviewedAt.Equal(created.Add(2*time.Hour)) {
+		t.Errorf("Expected first review from carol's comment at %v, got %v", created.Add(2*time.Hour), pr.FirstReviewedAt)
+	}
+	// bob's vote thread, carol's comment and dave's timestamp-less "waiting for author" vote
+	if len(pr.Reviews) != 3 {
+		t.Fatalf("Expected 3 reviews, got %+v", pr.Reviews)
+	}
+	if pr.Reviews[2].Reviewer != "dave@example.com" || pr.Reviews[2].State != "CHANGES_REQUESTED" {
+		t.Errorf("Expected dave's vote to map to CHANGES_REQUESTED, got %+v", pr.Reviews[2])
+	}
+	if len(pr.RequestedReviewers) != 3 || pr.RequestedReviewers[2] != `@[proj]\Infra Team` {
+		t.Errorf("Expected bob, dave and the Infra team as requested reviewers, got %v", pr.RequestedReviewers)
+	}
+	if pr.ChangedFiles != 3 {
+		t.Errorf("Expected 3 changed files from the latest iteration, got %d", pr.ChangedFiles)
+	}
+	if len(pr.Labels) != 1 || pr.Labels[0] != "infra" {
+		t.Errorf("Expected labels [infra], got %v", pr.Labels)
+	}
+}
+
+func TestGetPullRequests_DefaultPageSize(t *testing.T) {
+	server := setupMockAzureDevOpsServer(t, time.Now())
+
+	client := azuredevops.NewClient(&config.AzureDevOpsConfig{
+		Token:   "dummy_token",
+		OrgURL:  server.URL + "/org",
+		Project: "proj",
+		Repo:    "repo",
+	})
+
+	prs, err := client.GetPullRequests(context.Background(), "closed", 0)
+	if err != nil || len(prs) != 1 {
+		t.Errorf("Expected 1 pull request with the default page size, got %d (err %v)", len(prs), err)
+	}
+}
@@ -0,0 +1,65 @@
+package azuredevops
+
+import "time"
+
+// list is the envelope Azure DevOps wraps around collections.
+type list[T any] struct {
+	Value []T `json:"value"`
+	Count int `json:"count"`
+}
+
+// identity is the subset of an Azure DevOps identity we rely on.
+type identity struct {
+	UniqueName  string `json:"uniqueName"`
+	DisplayName string `json:"displayName"`
+}
+
+// reviewer is an identity together with its current vote on the pull request.
+type reviewer struct {
+	identity
+	Vote        int  `json:"vote"`        // 10 approved, 5 approved with suggestions, -5 waiting for author, -10 rejected
+	IsContainer bool `json:"isContainer"` // Set for groups and teams
+}
+
+// pullRequest mirrors the fields of the Azure Repos pull request API we map into PrData.
+type pullRequest struct {
+	PullRequestID int        `json:"pullRequestId"`
+	Title         string     `json:"title"`
+	Status        string     `json:"status"` // "active", "abandoned" or "completed"
+	CreatedBy     identity   `json:"createdBy"`
+	CreationDate  time.Time  `json:"creationDate"`
+	ClosedDate    *time.Time `json:"closedDate"`
+	Labels        []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Reviewers []reviewer `json:"reviewers"`
+}
+
+// thread is a comment thread; vote changes are recorded as system threads with properties.
+type thread struct {
+	PublishedDate time.Time `json:"publishedDate"`
+	Comments      []struct {
+		Author        identity  `json:"author"`
+		PublishedDate time.Time `json:"publishedDate"`
+		CommentType   string    `json:"commentType"` // "text" or "system"
+	} `json:"comments"`
+	Properties map[string]struct {
+		Value any `json:"$value"` // Strings for vote properties, but other properties carry numbers
+	} `json:"properties"`
+}
+
+// iteration is one push (update) of a pull request.
+type iteration struct {
+	ID int `json:"id"`
+}
+
+// iterationChanges lists the files changed as of an iteration.
+type iterationChanges struct {
+	ChangeEntries []struct {
+		ChangeType string `json:"changeType"`
+		Item       struct {
+			Path string `json:"path"`
+		} `json:"item"`
+	} `json:"changeEntries"`
+	NextSkip int `json:"nextSkip"`
+}
@@ -0,0 +1,169 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// maxPageLen is the largest pagelen the pull request endpoints accept.
+const maxPageLen = 50
+
+type Client struct {
+	httpClient *http.Client
+	config     *config.BitbucketConfig
+}
+
+func NewClient(cfg *config.BitbucketConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		config:     cfg,
+	}
+}
+
+// GetPullRequests fetches pull requests for the configured Bitbucket Cloud repository.
+// The state follows the GitHub vocabulary ("open", "closed", "all"); "closed" includes merged PRs.
+func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int) ([]*github.PrData, error) {
+	if perPage <= 0 {
+		perPage = github.DefaultPerPage
+	}
+	query := url.Values{}
+	for _, bbState := range bitbucketStates(state) {
+		query.Add("state", bbState)
+	}
+	query.Set("pagelen", strconv.Itoa(min(perPage, maxPageLen)))
+
+	prs, err := getAll[pullRequest](ctx, c, c.repoURL("pullrequests")+"?"+query.Encode())
+	if err != nil {
+		return nil, err
+	}
+
+	var allPrs []*github.PrData
+	for _, pr := range prs {
+		allPrs = append(allPrs, c.toPrData(ctx, pr))
+	}
+	return allPrs, nil
+}
+
+// toPrData enriches a pull request with its activity log and diffstat.
+func (c *Client) toPrData(ctx context.Context, pr pullRequest) *github.PrData {
+	prData := &github.PrData{
+		Number:    pr.ID,
+		Title:     pr.Title,
+		State:     "closed",
+		Author:    pr.Author.name(),
+		CreatedAt: pr.CreatedOn,
+	}
+	if pr.State == "OPEN" {
+		prData.State = "open"
+	} else if pr.State == "MERGED" {
+		prData.State = "merged"
+	}
+
+	activities, err := getAll[activity](ctx, c, c.repoURL(fmt.Sprintf("pullrequests/%d/activity", pr.ID)))
+	if err != nil {
+		log.Printf("Warning: Could not fetch activity for PR #%d: %v", pr.ID, err)
+	}
+	var closedAt *time.Time
+	for _, a := range activities {
+		switch {
+		case a.Approval != nil:
+			prData.Reviews = append(prData.Reviews, github.Review{Reviewer: a.Approval.User.name(), State: "APPROVED", SubmittedAt: a.Approval.Date})
+		case a.ChangesRequested != nil:
+			prData.Reviews = append(prData.Reviews, github.Review{Reviewer: a.ChangesRequested.User.name(), State: "CHANGES_REQUESTED", SubmittedAt: a.ChangesRequested.Date})
+		case a.Comment != nil && a.Comment.User.name() != prData.Author:
+			prData.Reviews = append(prData.Reviews, github.Review{Reviewer: a.Comment.User.name(), State: "COMMENTED", SubmittedAt: a.Comment.CreatedOn})
+		case a.Update != nil && a.Update.State == pr.State && pr.State != "OPEN":
+			date := a.Update.Date
+			closedAt = &date
+		}
+	}
+	if closedAt == nil && pr.State != "OPEN" {
+		// Fall back to the last update when the activity log doesn't record the transition
+		closedAt = &pr.UpdatedOn
+	}
+	if pr.State == "MERGED" {
+		prData.MergedAt = closedAt
+	}
+	prData.ClosedAt = closedAt
+	prData.FirstReviewedAt = github.EarliestReview(prData.Reviews)
+
+	stats, err := getAll[diffStat](ctx, c, c.repoURL(fmt.Sprintf("pullrequests/%d/diffstat", pr.ID)))
+	if err != nil {
+		log.Printf("Warning: Could not fetch diffstat for PR #%d: %v", pr.ID, err)
+	}
+	prData.ChangedFiles = len(stats)
+	for _, stat := range stats {
+		prData.Additions += stat.LinesAdded
+		prData.Deletions += stat.LinesRemoved
+	}
+	return prData
+}
+
+// getAll follows Bitbucket's "next" links and returns every value of a paginated collection.
+func getAll[T any](ctx context.Context, c *Client, u string) ([]T, error) {
+	var all []T
+	for u != "" {
+		var p page[T]
+		if err := c.get(ctx, u, &p); err != nil {
+			return all, err
+		}
+		all = append(all, p.Values...)
+		u = p.Next
+	}
+	return all, nil
+}
+
+// get performs an authenticated GET against an absolute API URL and decodes the JSON body into v.
+func (c *Client) get(ctx context.Context, u string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return err
+	}
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Token)
+	} else {
+		req.Header.Set("Authorization", "Bearer "+c.config.Token)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", req.URL.Path, resp.Status)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", req.URL.Path, err)
+	}
+	return nil
+}
+
+func (c *Client) repoURL(suffix string) string {
+	return fmt.Sprintf("%s/repositories/%s/%s/%s", strings.TrimRight(c.config.BaseURL, "/"),
+		url.PathEscape(c.config.Workspace), url.PathEscape(c.config.Repo), suffix)
+}
+
+// bitbucketStates translates a GitHub-style state filter into Bitbucket pull request states.
+func bitbucketStates(state string) []string {
+	switch state {
+	case "open":
+		return []string{"OPEN"}
+	case "closed":
+		return []string{"MERGED", "DECLINED", "SUPERSEDED"}
+	default:
+		return []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}
+	}
+}
@@ -0,0 +1,122 @@
+package bitbucket_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/bitbucket"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// setupMockBitbucketServer creates a stand-in for the Bitbucket Cloud 2.0 API.
+// The pull request list is split over two pages to exercise "next" links.
+func setupMockBitbucketServer(t *testing.T, created time.Time) *httptest.Server {
+	var server *httptest.Server
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repositories/ws/repo/pullrequests", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer dummy_token" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		if pagelen, _ := strconv.Atoi(r.URL.Query().Get("pagelen")); pagelen > 50 {
+			http.Error(w, "Invalid pagelen", http.StatusBadRequest)
+			return
+		}
+		if r.URL.Query().Get("page") == "2" {
+			writeJSON(w, map[string]any{"values": []map[string]any{
+				{"id": 2, "title": "Declined PR", "state": "DECLINED", "author": map[string]any{"nickname": "bob"},
+					"created_on": created, "updated_on": created.Add(10 * time.Hour)},
+			}})
+			return
+		}
+		writeJSON(w, map[string]any{
+			"values": []map[string]any{
+				{"id": 1, "title": "Merged PR", "state": "MERGED", "author": map[string]any{"nickname": "alice"},
+					"created_on": created, "updated_on": created.Add(50 * time.Hour)},
+			},
+			"next": server.URL + "/repositories/ws/repo/pullrequests?page=2",
+		})
+	})
+	mux.HandleFunc("/repositories/ws/repo/pullrequests/1/activity", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"values": []map[string]any{
+			{"update": map[string]any{"state": "MERGED", "date": created.Add(24 * time.Hour), "author": map[string]any{"nickname": "alice"}}},
+			{"approval": map[string]any{"date": created.Add(5 * time.Hour), "user": map[string]any{"nickname": "carol"}}},
+			{"comment": map[string]any{"created_on": created.Add(3 * time.Hour), "user": map[string]any{"nickname": "carol"}}},
+			{"comment": map[string]any{"created_on": created.Add(time.Hour), "user": map[string]any{"nickname": "alice"}}},
+		}})
+	})
+	mux.HandleFunc("/repositories/ws/repo/pullrequests/1/diffstat", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"values": []map[string]any{
+			{"lines_added": 40, "lines_removed": 10, "status": "modified"},
+			{"lines_added": 5, "lines_removed": 0, "status": "added"},
+		}})
+	})
+	mux.HandleFunc("/repositories/ws/repo/pullrequests/2/activity", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"values": []map[string]any{}})
+	})
+	mux.HandleFunc("/repositories/ws/repo/pullrequests/2/diffstat", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"values": []map[string]any{{"lines_added": 1, "lines_removed": 1}}})
+	})
+
+	server = httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPullRequests(t *testing.T) {
+	created := time.Date(2025, 4, 1, 8, 0, 0, 0, time.UTC)
+	server := setupMockBitbucketServer(t, created)
+
+	client := bitbucket.NewClient(&config.BitbucketConfig{
+		Token:     "dummy_token",
+		BaseURL:   server.URL,
+		Workspace: "ws",
+		Repo:      "repo",
+	})
+
+	prs, err := client.GetPullRequests(context.Background(), "closed", 1)
+	if err != nil {
+		t.Fatalf("GetPullRequests failed: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 pull requests across both pages, got %d", len(prs))
+	}
+	if _, err := client.GetPullRequests(context.Background(), "closed", 100); err != nil {
+		t.Errorf("Expected pages of 100 to be clamped to Bitbucket's maximum, got %v", err)
+	}
+
+	merged := prs[0]
+	if merged.State != "merged" || merged.MergedAt == nil || !merged.MergedAt.Equal(created.Add(24*time.Hour)) {
+		t.Errorf("Expected PR 1 merged at %v, got state %q at %v", created.Add(24*time.Hour), merged.State, merged.MergedAt)
+	}
+	if merged.FirstReviewedAt == nil || !merged.FirstReviewedAt.Equal(created.Add(3*time.Hour)) {
+		t.Errorf("Expected first review from carol's comment at %v, got %v", created.Add(3*time.Hour), merged.FirstReviewedAt)
+	}
+	if len(merged.Reviews) != 2 {
+		t.Errorf("Expected 2 reviews (author comment excluded), got %+v", merged.Reviews)
+	}
+	if merged.Additions != 45 || merged.Deletions != 10 || merged.ChangedFiles != 2 {
+		t.Errorf("Expected size +45 / -10 in 2 files, got +%d / -%d in %d files", merged.Additions, merged.Deletions, merged.ChangedFiles)
+	}
+
+	declined := prs[1]
+	if declined.State != "closed" || declined.MergedAt != nil {
+		t.Errorf("Expected PR 2 to be closed without merge, got state %q merged at %v", declined.State, declined.MergedAt)
+	}
+	if declined.ClosedAt == nil || !declined.ClosedAt.Equal(created.Add(10*time.Hour)) {
+		t.Errorf("Expected PR 2 ClosedAt to fall back to updated_on, got %v", declined.ClosedAt)
+	}
+	if declined.FirstReviewedAt != nil {
+		t.Errorf("Expected PR 2 to have no review, got %v", declined.FirstReviewedAt)
+	}
+}
@@ -0,0 +1,60 @@
+package bitbucket
+
+import "time"
+
+// page is the envelope Bitbucket Cloud wraps around every paginated collection.
+type page[T any] struct {
+	Values []T    `json:"values"`
+	Next   string `json:"next"` // Absolute URL of the next page, empty on the last page
+}
+
+// account is the subset of a Bitbucket user we rely on.
+type account struct {
+	Nickname    string `json:"nickname"`
+	DisplayName string `json:"display_name"`
+}
+
+func (a account) name() string {
+	if a.Nickname != "" {
+		return a.Nickname
+	}
+	return a.DisplayName
+}
+
+// pullRequest mirrors the fields of the Bitbucket pull request API we map into PrData.
+type pullRequest struct {
+	ID        int       `json:"id"`
+	Title     string    `json:"title"`
+	State     string    `json:"state"` // "OPEN", "MERGED", "DECLINED" or "SUPERSEDED"
+	Author    account   `json:"author"`
+	CreatedOn time.Time `json:"created_on"`
+	UpdatedOn time.Time `json:"updated_on"`
+}
+
+// activity is one entry of a pull request's activity log; exactly one field is set.
+type activity struct {
+	Approval *struct {
+		Date time.Time `json:"date"`
+		User account   `json:"user"`
+	} `json:"approval"`
+	ChangesRequested *struct {
+		Date time.Time `json:"date"`
+		User account   `json:"user"`
+	} `json:"changes_requested"`
+	Comment *struct {
+		CreatedOn time.Time `json:"created_on"`
+		User      account   `json:"user"`
+	} `json:"comment"`
+	Update *struct {
+		State  string    `json:"state"`
+		Date   time.Time `json:"date"`
+		Author account   `json:"author"`
+	} `json:"update"`
+}
+
+// diffStat holds line counts for a single changed file.
+type diffStat struct {
+	LinesAdded   int    `json:"lines_added"`
+	LinesRemoved int    `json:"lines_removed"`
+	Status       string `json:"status"`
+}
@@ -0,0 +1,208 @@
+package gerrit
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// xssiPrefix is prepended by Gerrit to every JSON response.
+const xssiPrefix = ")]}'"
+
+// codeReviewVote matches votes such as "Patch Set 2: Code-Review+2" in change messages.
+var codeReviewVote = regexp.MustCompile(`Code-Review([+-]\d+)`)
+
+type Client struct {
+	httpClient *http.Client
+	config     *config.GerritConfig
+}
+
+func NewClient(cfg *config.GerritConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		config:     cfg,
+	}
+}
+
+// GetPullRequests fetches changes for the configured Gerrit project and maps them into PrData.
+// Each patch set counts as a review round, the first Code-Review vote as the first review,
+// and the submit time as the merge time.
+func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int) ([]*github.PrData, error) {
+	if perPage <= 0 {
+		perPage = github.DefaultPerPage
+	}
+	query := url.Values{}
+	query.Set("q", "project:"+c.config.Project+gerritStatus(state))
+	query.Set("n", strconv.Itoa(perPage))
+	query["o"] = []string{"ALL_REVISIONS", "CURRENT_FILES", "DETAILED_LABELS", "DETAILED_ACCOUNTS", "MESSAGES"}
+
+	var allPrs []*github.PrData
+	for start := 0; ; {
+		query.Set("S", strconv.Itoa(start))
+		var changes []change
+		if err := c.get(ctx, "changes/", query, &changes); err != nil {
+			return nil, err
+		}
+		for _, ch := range changes {
+			allPrs = append(allPrs, toPrData(ch))
+		}
+		start += len(changes)
+		if len(changes) == 0 || !changes[len(changes)-1].MoreChanges {
+			break
+		}
+	}
+	return allPrs, nil
+}
+
+// toPrData maps a change, its patch sets and its Code-Review votes into PrData.
+func toPrData(ch change) *github.PrData {
+	prData := &github.PrData{
+		Number:    ch.Number,
+		Title:     ch.Subject,
+		Author:    ch.Owner.name(),
+		CreatedAt: ch.Created.Time,
+		Additions: ch.Insertions,
+		Deletions: ch.Deletions,
+		Labels:    ch.Hashtags,
+	}
+	switch ch.Status {
+	case "NEW":
+		prData.State = "open"
+	case "MERGED":
+		prData.State = "merged"
+		if ch.Submitted != nil {
+			submitted := ch.Submitted.Time
+			prData.MergedAt = &submitted
+			prData.ClosedAt = &submitted
+		}
+	default:
+		prData.State = "closed"
+		updated := ch.Updated.Time
+		prData.ClosedAt = &updated
+	}
+
+	for _, rev := range ch.Revisions {
+		if rev.Number > prData.ReviewRounds {
+			prData.ReviewRounds = rev.Number
+		}
+		files := 0
+		for path := range rev.Files {
+			if !strings.HasPrefix(path, "/") { // Skip magic files like /COMMIT_MSG
+				files++
+			}
+		}
+		if files > prData.ChangedFiles {
+			prData.ChangedFiles = files
+		}
+	}
+
+	prData.Reviews = votesFromMessages(ch.Messages, ch.Owner.AccountID)
+	if len(prData.Reviews) == 0 {
+		// Messages may be trimmed; fall back to the votes currently recorded on the label
+		for _, vote := range ch.Labels["Code-Review"].All {
+			if vote.Value == 0 || vote.AccountID == ch.Owner.AccountID {
+				continue
+			}
+			prData.Reviews = append(prData.Reviews, github.Review{
+				Reviewer:    vote.name(),
+				State:       voteState(vote.Value),
+				SubmittedAt: vote.Date.Time,
+			})
+		}
+	}
+	prData.FirstReviewedAt = github.EarliestReview(prData.Reviews)
+	return prData
+}
+
+// votesFromMessages extracts every Code-Review vote cast by someone other than the owner.
+func votesFromMessages(messages []message, ownerID int) []github.Review {
+	var reviews []github.Review
+	for _, m := range messages {
+		if m.Author.AccountID == ownerID {
+			continue
+		}
+		firstLine, _, _ := strings.Cut(m.Message, "\n")
+		match := codeReviewVote.FindStringSubmatch(firstLine)
+		if match == nil {
+			continue
+		}
+		value, err := strconv.Atoi(match[1])
+		if err != nil || value == 0 {
+			continue
+		}
+		reviews = append(reviews, github.Review{
+			Reviewer:    m.Author.name(),
+			State:       voteState(value),
+			SubmittedAt: m.Date.Time,
+		})
+	}
+	return reviews
+}
+
+// voteState maps a Code-Review value onto the GitHub review state vocabulary.
+func voteState(value int) string {
+	switch {
+	case value >= 2:
+		return "APPROVED"
+	case value < 0:
+		return "CHANGES_REQUESTED"
+	default:
+		return "COMMENTED"
+	}
+}
+
+// get performs a GET against the Gerrit REST API, strips the XSSI prefix and decodes the body into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) error {
+	base := strings.TrimRight(c.config.BaseURL, "/") + "/"
+	if c.config.Username != "" {
+		base += "a/" // Authenticated endpoints live under /a/
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, base+path+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	if c.config.Username != "" {
+		req.SetBasicAuth(c.config.Username, c.config.Password)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("GET %s: unexpected status %s", path, resp.Status)
+	}
+	body := bufio.NewReader(resp.Body)
+	if prefix, err := body.Peek(len(xssiPrefix)); err == nil && string(prefix) == xssiPrefix {
+		body.ReadString('\n')
+	}
+	if err := json.NewDecoder(body).Decode(v); err != nil {
+		return fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return nil
+}
+
+// gerritStatus translates a GitHub-style state filter into a Gerrit search operator.
+func gerritStatus(state string) string {
+	switch state {
+	case "open":
+		return " status:open"
+	case "closed":
+		return " (status:merged OR status:abandoned)"
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,80 @@
+package gerrit_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/gerrit"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// setupFixtureServer serves testdata/changes.json, including Gerrit's XSSI prefix, for closed-change queries.
+func setupFixtureServer(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/changes/", func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if !strings.HasPrefix(q.Get("q"), "project:tools/estimator") || !strings.Contains(q.Get("q"), "status:merged") {
+			http.Error(w, "Unexpected query "+q.Get("q"), http.StatusBadRequest)
+			return
+		}
+		if len(q["o"]) == 0 {
+			http.Error(w, "Missing query options", http.StatusBadRequest)
+			return
+		}
+		http.ServeFile(w, r, "testdata/changes.json")
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPullRequests(t *testing.T) {
+	server := setupFixtureServer(t)
+	client := gerrit.NewClient(&config.GerritConfig{
+		BaseURL: server.URL,
+		Project: "tools/estimator",
+	})
+
+	prs, err := client.GetPullRequests(context.Background(), "closed", 25)
+	if err != nil {
+		t.Fatalf("GetPullRequests failed: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 changes, got %d", len(prs))
+	}
+
+	merged := prs[0]
+	if merged.Number != 4211 || merged.State != "merged" || merged.Author != "alice" {
+		t.Errorf("Unexpected change identity: %+v", merged)
+	}
+	if merged.ReviewRounds != 3 {
+		t.Errorf("Expected 3 review rounds (patch sets), got %d", merged.ReviewRounds)
+	}
+	expectedFirstReview := time.Date(2025, 6, 2, 13, 0, 0, 0, time.UTC)
+	if merged.FirstReviewedAt == nil || !merged.FirstReviewedAt.Equal(expectedFirstReview) {
+		t.Errorf("Expected first review at carol's -1 (%v), got %v", expectedFirstReview, merged.FirstReviewedAt)
+	}
+	if len(merged.Reviews) != 2 {
+		t.Errorf("Expected 2 votes (owner's self-vote excluded), got %+v", merged.Reviews)
+	}
+	expectedMerge := time.Date(2025, 6, 3, 11, 30, 0, 0, time.UTC)
+	if merged.MergedAt == nil || !merged.MergedAt.Equal(expectedMerge) {
+		t.Errorf("Expected MergedAt at submit time %v, got %v", expectedMerge, merged.MergedAt)
+	}
+	if merged.Additions != 120 || merged.Deletions != 30 || merged.ChangedFiles != 2 {
+		t.Errorf("Expected size +120 / -30 in 2 files, got +%d / -%d in %d files", merged.Additions, merged.Deletions, merged.ChangedFiles)
+	}
+
+	abandoned := prs[1]
+	if abandoned.State != "closed" || abandoned.MergedAt != nil || abandoned.ClosedAt == nil {
+		t.Errorf("Expected abandoned change to be closed without merge, got %+v", abandoned)
+	}
+	if abandoned.FirstReviewedAt != nil || abandoned.ReviewRounds != 1 {
+		t.Errorf("Expected abandoned change to have 1 round and no review, got %+v", abandoned)
+	}
+}
@@ -0,0 +1,86 @@
+package gerrit
+
+import (
+	"strings"
+	"time"
+)
+
+// timestampLayout is the format Gerrit uses for all timestamps (always UTC).
+const timestampLayout = "2006-01-02 15:04:05.000000000"
+
+// timestamp decodes Gerrit's quoted "2006-01-02 15:04:05.000000000" timestamps.
+type timestamp struct {
+	time.Time
+}
+
+func (t *timestamp) UnmarshalJSON(data []byte) error {
+	s := strings.Trim(string(data), `"`)
+	if s == "" || s == "null" {
+		return nil
+	}
+	parsed, err := time.Parse(timestampLayout, s)
+	if err != nil {
+		return err
+	}
+	t.Time = parsed
+	return nil
+}
+
+// account is the subset of a Gerrit account we rely on.
+type account struct {
+	AccountID int    `json:"_account_id"`
+	Username  string `json:"username"`
+	Name      string `json:"name"`
+}
+
+func (a account) name() string {
+	if a.Username != "" {
+		return a.Username
+	}
+	return a.Name
+}
+
+// revision is a single patch set of a change.
+type revision struct {
+	Number  int       `json:"_number"`
+	Created timestamp `json:"created"`
+	Files   map[string]struct {
+		LinesInserted int `json:"lines_inserted"`
+		LinesDeleted  int `json:"lines_deleted"`
+	} `json:"files"` // Only populated for the current revision
+}
+
+// approval is a single vote on a label.
+type approval struct {
+	account
+	Value int       `json:"value"`
+	Date  timestamp `json:"date"`
+}
+
+// message is an entry of the change log, e.g. "Patch Set 2: Code-Review+2".
+type message struct {
+	Author         account   `json:"author"`
+	Date           timestamp `json:"date"`
+	Message        string    `json:"message"`
+	RevisionNumber int       `json:"_revision_number"`
+}
+
+// change mirrors the fields of the Gerrit change API we map into PrData.
+type change struct {
+	Number     int                 `json:"_number"`
+	Subject    string              `json:"subject"`
+	Status     string              `json:"status"` // "NEW", "MERGED" or "ABANDONED"
+	Owner      account             `json:"owner"`
+	Created    timestamp           `json:"created"`
+	Updated    timestamp           `json:"updated"`
+	Submitted  *timestamp          `json:"submitted"`
+	Insertions int                 `json:"insertions"`
+	Deletions  int                 `json:"deletions"`
+	Hashtags   []string            `json:"hashtags"`
+	Revisions  map[string]revision `json:"revisions"`
+	Labels     map[string]struct {
+		All []approval `json:"all"`
+	} `json:"labels"`
+	Messages    []message `json:"messages"`
+	MoreChanges bool      `json:"_more_changes"` // Set on the last change of a truncated page
+}
@@ -2,7 +2,13 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"log"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strings"
 	"time"
 
 	gh "github.com/google/go-github/v63/github"
@@ -23,8 +29,18 @@ func NewClient(cfg *config.GitHubConfig) *Client {
 	)
 	tc := oauth2.NewClient(ctx, ts)
 
+	ghClient := gh.NewClient(tc)
+	if cfg.BaseURL != "" {
+		baseURL, err := url.Parse(strings.TrimSuffix(cfg.BaseURL, "/") + "/")
+		if err != nil {
+			log.Printf("Warning: Ignoring invalid GitHub API URL %q: %v", cfg.BaseURL, err)
+		} else {
+			ghClient.BaseURL = baseURL
+		}
+	}
+
 	return &Client{
-		ghClient: gh.NewClient(tc),
+		ghClient: ghClient,
 		config:   cfg,
 	}
 }
@@ -32,13 +48,34 @@ func NewClient(cfg *config.GitHubConfig) *Client {
 // GetPullRequests fetches a list of pull requests for the configured repository.
 // It can be filtered by state (e.g., "closed", "all").
 func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int) ([]*PrData, error) {
+	if perPage <= 0 {
+		perPage = DefaultPerPage
+	}
 	opts := &gh.PullRequestListOptions{
 		State: state,
 		ListOptions: gh.ListOptions{
 			PerPage: perPage,
 		},
 	}
+	return c.listPullRequests(ctx, opts, 0, nil)
+}
 
+// GetMergedPullRequests fetches up to limit of the most recently updated merged pull requests.
+// Each PR costs several API calls, so this keeps callers such as the Actions mode within the
+// rate limit of GITHUB_TOKEN; unmerged PRs are skipped without fetching their details.
+func (c *Client) GetMergedPullRequests(ctx context.Context, limit int) ([]*PrData, error) {
+	opts := &gh.PullRequestListOptions{
+		State:       "closed",
+		Sort:        "updated",
+		Direction:   "desc",
+		ListOptions: gh.ListOptions{PerPage: min(limit, DefaultPerPage)},
+	}
+	return c.listPullRequests(ctx, opts, limit, func(pr *gh.PullRequest) bool { return pr.MergedAt != nil })
+}
+
+// listPullRequests fetches the details of the listed PRs that keep accepts, or of all of them
+// when keep is nil, stopping after limit PRs unless limit is 0.
+func (c *Client) listPullRequests(ctx context.Context, opts *gh.PullRequestListOptions, limit int, keep func(*gh.PullRequest) bool) ([]*PrData, error) {
 	var allPrs []*PrData
 	for {
 		prs, resp, err := c.ghClient.PullRequests.List(ctx, c.config.Owner, c.config.Repo, opts)
@@ -47,47 +84,225 @@ func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int)
 		}
 
 		for _, pr := range prs {
-			// Fetch detailed PR to get additions/deletions/changed files
-			detailedPR, _, err := c.ghClient.PullRequests.Get(ctx, c.config.Owner, c.config.Repo, pr.GetNumber())
+			if keep != nil && !keep(pr) {
+				continue
+			}
+			prData, err := c.GetPullRequest(ctx, pr.GetNumber())
 			if err != nil {
 				log.Printf("Warning: Could not fetch detailed PR #%d: %v", pr.GetNumber(), err)
 				continue
 			}
+			allPrs = append(allPrs, prData)
+			if limit > 0 && len(allPrs) >= limit {
+				return allPrs, nil
+			}
+		}
 
-			// Fetch reviews to find the first review time
-			reviews, _, err := c.ghClient.PullRequests.ListReviews(ctx, c.config.Owner, c.config.Repo, pr.GetNumber(), nil)
-			var firstReviewedAt *time.Time
-			if err == nil && len(reviews) > 0 {
-				// Sort reviews by creation time to find the first
-				earliestReviewTime := reviews[0].GetSubmittedAt()
-				for _, review := range reviews {
-					if review.GetSubmittedAt().Before(earliestReviewTime.Time) {
-						earliestReviewTime = review.GetSubmittedAt()
-					}
-				}
-				firstReviewedAt = &earliestReviewTime.Time
-			} else if err != nil {
-				log.Printf("Warning: Could not fetch reviews for PR #%d: %v", pr.GetNumber(), err)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+	return allPrs, nil
+}
+
+// GetPullRequest fetches a single pull request with its reviews and timeline.
+func (c *Client) GetPullRequest(ctx context.Context, number int) (*PrData, error) {
+	// Fetch detailed PR to get additions/deletions/changed files
+	detailedPR, _, err := c.ghClient.PullRequests.Get(ctx, c.config.Owner, c.config.Repo, number)
+	if err != nil {
+		return nil, err
+	}
+
+	// Fetch reviews to find the first review time
+	reviews, _, err := c.ghClient.PullRequests.ListReviews(ctx, c.config.Owner, c.config.Repo, number, nil)
+	var firstReviewedAt *time.Time
+	if err == nil && len(reviews) > 0 {
+		// Sort reviews by creation time to find the first
+		earliestReviewTime := reviews[0].GetSubmittedAt()
+		for _, review := range reviews {
+			if review.GetSubmittedAt().Before(earliestReviewTime.Time) {
+				earliestReviewTime = review.GetSubmittedAt()
 			}
-			mergedAt := detailedPR.GetMergedAt().Time
-			closedAt := detailedPR.GetClosedAt().Time
-			prData := &PrData{
-				Number:          detailedPR.GetNumber(),
-				Title:           detailedPR.GetTitle(),
-				State:           detailedPR.GetState(),
-				Author:          detailedPR.GetUser().GetLogin(),
-				CreatedAt:       detailedPR.GetCreatedAt().Time,
-				MergedAt:        &mergedAt,
-				ClosedAt:        &closedAt,
-				Additions:       detailedPR.GetAdditions(),
-				Deletions:       detailedPR.GetDeletions(),
-				ChangedFiles:    detailedPR.GetChangedFiles(),
-				FirstReviewedAt: firstReviewedAt,
+		}
+		firstReviewedAt = &earliestReviewTime.Time
+	} else if err != nil {
+		log.Printf("Warning: Could not fetch reviews for PR #%d: %v", number, err)
+	}
+	prData := &PrData{
+		Number:          detailedPR.GetNumber(),
+		Title:           detailedPR.GetTitle(),
+		State:           detailedPR.GetState(),
+		Author:          detailedPR.GetUser().GetLogin(),
+		CreatedAt:       detailedPR.GetCreatedAt().Time,
+		Additions:       detailedPR.GetAdditions(),
+		Deletions:       detailedPR.GetDeletions(),
+		ChangedFiles:    detailedPR.GetChangedFiles(),
+		FirstReviewedAt: firstReviewedAt,
+		BaseSHA:         detailedPR.GetBase().GetSHA(),
+		HeadSHA:         detailedPR.GetHead().GetSHA(),
+	}
+	if detailedPR.MergedAt != nil {
+		mergedAt := detailedPR.GetMergedAt().Time
+		prData.MergedAt = &mergedAt
+	}
+	if detailedPR.ClosedAt != nil {
+		closedAt := detailedPR.GetClosedAt().Time
+		prData.ClosedAt = &closedAt
+	}
+	for _, label := range detailedPR.Labels {
+		prData.Labels = append(prData.Labels, label.GetName())
+	}
+	for _, review := range reviews {
+		prData.Reviews = append(prData.Reviews, Review{
+			Reviewer:    review.GetUser().GetLogin(),
+			State:       review.GetState(),
+			SubmittedAt: review.GetSubmittedAt().Time,
+		})
+	}
+	files, err := c.listFiles(ctx, number)
+	if err != nil {
+		log.Printf("Warning: Could not fetch files for PR #%d: %v", number, err)
+	}
+	prData.Files = files
+	if prData.BaseSHA != "" && prData.HeadSHA != "" && slices.ContainsFunc(files, func(f FileChange) bool { return path.Ext(f.Path) == ".go" }) {
+		// base.sha is the tip of the base branch, which may contain changes made after the PR
+		// branched off; Go complexity is measured against the merge base instead
+		comparison, _, err := c.ghClient.Repositories.CompareCommits(ctx, c.config.Owner, c.config.Repo, prData.BaseSHA, prData.HeadSHA, &gh.ListOptions{PerPage: 1})
+		if err != nil {
+			log.Printf("Warning: Could not find the merge base of PR #%d, using the base branch: %v", number, err)
+		} else if sha := comparison.GetMergeBaseCommit().GetSHA(); sha != "" {
+			prData.BaseSHA = sha
+		}
+	}
+	timeline, err := c.getTimeline(ctx, number)
+	if err != nil {
+		log.Printf("Warning: Could not fetch timeline for PR #%d: %v", number, err)
+	}
+	prData.Timeline = timeline
+
+	// Pending requests are listed on the PR; requests that were answered only show in the timeline
+	for _, u := range detailedPR.RequestedReviewers {
+		prData.RequestedReviewers = appendUnique(prData.RequestedReviewers, u.GetLogin())
+	}
+	for _, team := range detailedPR.RequestedTeams {
+		prData.RequestedReviewers = appendUnique(prData.RequestedReviewers, c.teamName(team))
+	}
+	for _, event := range timeline {
+		if event.Event == "review_requested" && event.Subject != "" {
+			prData.RequestedReviewers = appendUnique(prData.RequestedReviewers, event.Subject)
+		}
+	}
+	return prData, nil
+}
+
+// teamName returns a team in the "@org/team" form also used by CODEOWNERS.
+func (c *Client) teamName(team *gh.Team) string {
+	return "@" + c.config.Owner + "/" + team.GetSlug()
+}
+
+func appendUnique(values []string, value string) []string {
+	if slices.Contains(values, value) {
+		return values
+	}
+	return append(values, value)
+}
+
+// listFiles pages through the files changed by a PR.
+func (c *Client) listFiles(ctx context.Context, number int) ([]FileChange, error) {
+	opts := &gh.ListOptions{PerPage: 100}
+	var files []FileChange
+	for {
+		commitFiles, resp, err := c.ghClient.PullRequests.ListFiles(ctx, c.config.Owner, c.config.Repo, number, opts)
+		if err != nil {
+			return files, err
+		}
+		for _, f := range commitFiles {
+			files = append(files, FileChange{
+				Path:         f.GetFilename(),
+				PreviousPath: f.GetPreviousFilename(),
+				Additions:    f.GetAdditions(),
+				Deletions:    f.GetDeletions(),
+				Status:       f.GetStatus(),
+			})
+		}
+		if resp.NextPage == 0 {
+			return files, nil
+		}
+		opts.Page = resp.NextPage
+	}
+}
+
+// GetFile reads a file at ref, or from the default branch when ref is empty.
+func (c *Client) GetFile(ctx context.Context, path, ref string) ([]byte, error) {
+	file, _, resp, err := c.ghClient.Repositories.GetContents(ctx, c.config.Owner, c.config.Repo, path, &gh.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", path, ErrFileNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%s is a directory", path)
+	}
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(content), nil
+}
+
+// ListDirectory returns the names of the entries of dir at ref, or on the default branch when
+// ref is empty.
+func (c *Client) ListDirectory(ctx context.Context, dir, ref string) ([]string, error) {
+	if dir == "." {
+		dir = ""
+	}
+	_, entries, resp, err := c.ghClient.Repositories.GetContents(ctx, c.config.Owner, c.config.Repo, dir, &gh.RepositoryContentGetOptions{Ref: ref})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", dir, ErrFileNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.GetName())
+	}
+	return names, nil
+}
+
+// getTimeline fetches the issue timeline of a PR, keeping the events relevant to review flow.
+func (c *Client) getTimeline(ctx context.Context, number int) ([]TimelineEvent, error) {
+	opts := &gh.ListOptions{PerPage: 100}
+	var events []TimelineEvent
+	for {
+		timeline, resp, err := c.ghClient.Issues.ListIssueTimeline(ctx, c.config.Owner, c.config.Repo, number, opts)
+		if err != nil {
+			return events, err
+		}
+
+		for _, t := range timeline {
+			event := TimelineEvent{
+				Event:     t.GetEvent(),
+				Actor:     t.GetActor().GetLogin(),
+				CreatedAt: t.GetCreatedAt().Time,
 			}
-			for _, label := range detailedPR.Labels {
-				prData.Labels = append(prData.Labels, label.GetName())
+			switch event.Event {
+			case "committed":
+				// Commits carry their own dates instead of created_at
+				event.Actor = t.GetAuthor().GetName()
+				event.CreatedAt = t.GetCommitter().GetDate().Time
+			case "reviewed":
+				event.Actor = t.GetUser().GetLogin()
+				event.CreatedAt = t.GetSubmittedAt().Time
+			case "review_requested", "review_request_removed":
+				event.Subject = t.GetReviewer().GetLogin()
+				if t.RequestedTeam != nil {
+					event.Subject = c.teamName(t.GetRequestedTeam())
+				}
 			}
-			allPrs = append(allPrs, prData)
+			events = append(events, event)
 		}
 
 		if resp.NextPage == 0 {
@@ -95,5 +310,51 @@ func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int)
 		}
 		opts.Page = resp.NextPage
 	}
-	return allPrs, nil
+	return events, nil
+}
+
+// UpsertComment keeps a single "sticky" comment on a PR: the first comment containing marker
+// is edited in place, otherwise a new comment is created.
+func (c *Client) UpsertComment(ctx context.Context, number int, marker, body string) error {
+	opts := &gh.IssueListCommentsOptions{ListOptions: gh.ListOptions{PerPage: 100}}
+	for {
+		comments, resp, err := c.ghClient.Issues.ListComments(ctx, c.config.Owner, c.config.Repo, number, opts)
+		if err != nil {
+			return err
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), marker) {
+				_, _, err := c.ghClient.Issues.EditComment(ctx, c.config.Owner, c.config.Repo, comment.GetID(), &gh.IssueComment{Body: &body})
+				return err
+			}
+		}
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	_, _, err := c.ghClient.Issues.CreateComment(ctx, c.config.Owner, c.config.Repo, number, &gh.IssueComment{Body: &body})
+	return err
+}
+
+// ReplaceLabel applies label to a PR after removing any other label starting with prefix,
+// so PRs carry a single up-to-date label such as "review-eta:1d".
+func (c *Client) ReplaceLabel(ctx context.Context, number int, prefix, label string) error {
+	labels, _, err := c.ghClient.Issues.ListLabelsByIssue(ctx, c.config.Owner, c.config.Repo, number, &gh.ListOptions{PerPage: 100})
+	if err != nil {
+		return err
+	}
+	for _, l := range labels {
+		if l.GetName() == label {
+			return nil
+		}
+		if strings.HasPrefix(l.GetName(), prefix) {
+			if _, err := c.ghClient.Issues.RemoveLabelForIssue(ctx, c.config.Owner, c.config.Repo, number, l.GetName()); err != nil {
+				return err
+			}
+		}
+	}
+	_, _, err = c.ghClient.Issues.AddLabelsToIssue(ctx, c.config.Owner, c.config.Repo, number, []string{label})
+	return err
 }
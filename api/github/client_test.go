@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -216,3 +217,107 @@ func TestGetPullRequests(t *testing.T) {
 // func (c *Client) GhClient() *gh.Client {
 // 	return c.ghClient
 // }
+
+func TestGetPullRequest_ClosedUnmergedHasNoMergedAt(t *testing.T) {
+	server, cleanup := setupMockGitHubServer(t)
+	defer cleanup()
+
+	client := github.NewClient(&config.GitHubConfig{Token: "dummy_token", Owner: "test_owner", Repo: "test_repo", BaseURL: server.URL})
+	pr, err := client.GetPullRequest(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+	if pr.MergedAt != nil {
+		t.Errorf("Expected no MergedAt for a closed unmerged PR, got %v", pr.MergedAt)
+	}
+	if pr.ClosedAt == nil {
+		t.Error("Expected ClosedAt to be set")
+	}
+
+	// A merged PR without closed_at in the payload keeps ClosedAt unset instead of the zero time
+	pr, err = client.GetPullRequest(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+	if pr.MergedAt == nil || pr.ClosedAt != nil {
+		t.Errorf("Expected MergedAt only, got MergedAt %v, ClosedAt %v", pr.MergedAt, pr.ClosedAt)
+	}
+}
+
+func TestGetPullRequest_UsesMergeBaseForGoChanges(t *testing.T) {
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	mux.HandleFunc("/repos/test_owner/test_repo/pulls/3", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{
+			"number": 3,
+			"state":  "open",
+			"base":   map[string]any{"sha": "t1p"},
+			"head":   map[string]any{"sha": "he4d"},
+		})
+	})
+	mux.HandleFunc("/repos/test_owner/test_repo/pulls/3/files", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []map[string]any{{"filename": "cache/cache.go", "status": "modified", "additions": 3}})
+	})
+	mux.HandleFunc("/repos/test_owner/test_repo/compare/t1p...he4d", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, map[string]any{"merge_base_commit": map[string]any{"sha": "m3rge"}})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(&config.GitHubConfig{Token: "dummy_token", Owner: "test_owner", Repo: "test_repo", BaseURL: server.URL})
+	pr, err := client.GetPullRequest(context.Background(), 3)
+	if err != nil {
+		t.Fatalf("GetPullRequest failed: %v", err)
+	}
+	if pr.BaseSHA != "m3rge" || pr.HeadSHA != "he4d" {
+		t.Errorf("Expected the merge base m3rge and head he4d, got %q and %q", pr.BaseSHA, pr.HeadSHA)
+	}
+}
+
+func TestGetMergedPullRequests_StopsAtLimit(t *testing.T) {
+	mux := http.NewServeMux()
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+	merged := time.Now().Add(-time.Hour)
+	mux.HandleFunc("/repos/test_owner/test_repo/pulls", func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("state") != "closed" || q.Get("sort") != "updated" || q.Get("per_page") != "2" {
+			http.Error(w, "Unexpected query "+r.URL.RawQuery, http.StatusBadRequest)
+			return
+		}
+		writeJSON(w, []map[string]any{
+			{"number": 5, "merged_at": merged},
+			{"number": 4}, // Closed without merging
+			{"number": 3, "merged_at": merged},
+			{"number": 2, "merged_at": merged},
+		})
+	})
+	var fetched []string
+	mux.HandleFunc("/repos/test_owner/test_repo/pulls/", func(w http.ResponseWriter, r *http.Request) {
+		number := strings.TrimPrefix(r.URL.Path, "/repos/test_owner/test_repo/pulls/")
+		if strings.Contains(number, "/") {
+			writeJSON(w, []any{})
+			return
+		}
+		fetched = append(fetched, number)
+		writeJSON(w, map[string]any{"number": len(fetched), "state": "closed", "merged_at": merged})
+	})
+	mux.HandleFunc("/repos/test_owner/test_repo/issues/", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, []any{})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(&config.GitHubConfig{Token: "dummy_token", Owner: "test_owner", Repo: "test_repo", BaseURL: server.URL})
+	prs, err := client.GetMergedPullRequests(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("GetMergedPullRequests failed: %v", err)
+	}
+	if len(prs) != 2 || strings.Join(fetched, ",") != "5,3" {
+		t.Errorf("Expected only the merged PRs #5 and #3 to be fetched, got %d PRs from %v", len(prs), fetched)
+	}
+}
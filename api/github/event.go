@@ -0,0 +1,64 @@
+package github
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PullRequestEvent is the subset of GitHub's pull_request webhook/Actions event payload we use.
+type PullRequestEvent struct {
+	Action      string `json:"action"`
+	Number      int    `json:"number"`
+	PullRequest struct {
+		Number       int        `json:"number"`
+		Title        string     `json:"title"`
+		State        string     `json:"state"`
+		Draft        bool       `json:"draft"`
+		CreatedAt    time.Time  `json:"created_at"`
+		MergedAt     *time.Time `json:"merged_at"`
+		Additions    int        `json:"additions"`
+		Deletions    int        `json:"deletions"`
+		ChangedFiles int        `json:"changed_files"`
+		User         struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+	} `json:"pull_request"`
+	Repository struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// ParsePullRequestEvent decodes a pull_request event payload, failing for payloads without a pull request.
+func ParsePullRequestEvent(payload []byte) (*PullRequestEvent, error) {
+	var event PullRequestEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, err
+	}
+	if event.PullRequest.Number == 0 {
+		return nil, fmt.Errorf("event payload does not contain a pull request")
+	}
+	return &event, nil
+}
+
+// PrData converts the event's pull request into the shared PR model.
+func (e *PullRequestEvent) PrData() *PrData {
+	pr := &PrData{
+		Number:       e.PullRequest.Number,
+		Title:        e.PullRequest.Title,
+		State:        e.PullRequest.State,
+		Author:       e.PullRequest.User.Login,
+		CreatedAt:    e.PullRequest.CreatedAt,
+		MergedAt:     e.PullRequest.MergedAt,
+		Additions:    e.PullRequest.Additions,
+		Deletions:    e.PullRequest.Deletions,
+		ChangedFiles: e.PullRequest.ChangedFiles,
+	}
+	for _, label := range e.PullRequest.Labels {
+		pr.Labels = append(pr.Labels, label.Name)
+	}
+	return pr
+}
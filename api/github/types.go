@@ -1,19 +1,105 @@
 package github
 
-import "time"
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrFileNotFound is returned, wrapped, by the GetFile methods of all clients when the file
+// doesn't exist at the requested ref.
+var ErrFileNotFound = errors.New("file not found")
+
+// DefaultPerPage is the page size clients fetch pull requests with when given none.
+const DefaultPerPage = 100
 
 // PrData represents simplified pull request information
 type PrData struct {
-	Number          int
-	Title           string
-	State           string // e.g., "open", "closed", "merged"
-	Author          string
-	CreatedAt       time.Time
-	MergedAt        *time.Time // Pointer as it can be nil if not merged
-	ClosedAt        *time.Time // Pointer as it can be nil if not closed
-	Additions       int
-	Deletions       int
-	ChangedFiles    int
-	FirstReviewedAt *time.Time // Timestamp of the first review
-	Labels          []string   // Labels applied to the PR
+	Number             int             `json:"number"`
+	Title              string          `json:"title"`
+	State              string          `json:"state"` // e.g., "open", "closed", "merged"
+	Author             string          `json:"author"`
+	CreatedAt          time.Time       `json:"created_at"`
+	MergedAt           *time.Time      `json:"merged_at,omitempty"` // Pointer as it can be nil if not merged
+	ClosedAt           *time.Time      `json:"closed_at,omitempty"` // Pointer as it can be nil if not closed
+	Additions          int             `json:"additions"`
+	Deletions          int             `json:"deletions"`
+	ChangedFiles       int             `json:"changed_files"`
+	FirstReviewedAt    *time.Time      `json:"first_reviewed_at,omitempty"`   // Timestamp of the first review
+	Labels             []string        `json:"labels,omitempty"`              // Labels applied to the PR
+	BaseSHA            string          `json:"base_sha,omitempty"`            // Commit the changes apply to, where known
+	HeadSHA            string          `json:"head_sha,omitempty"`            // Last commit of the changes, where known
+	Files              []FileChange    `json:"files,omitempty"`               // Changed files, where the code host provides them
+	Features           *Features       `json:"features,omitempty"`            // Derived from Files, nil without file data
+	Reviews            []Review        `json:"reviews,omitempty"`             // Reviews submitted on the PR, in no particular order
+	RequestedReviewers []string        `json:"requested_reviewers,omitempty"` // Users (and "@org/team" teams) a review was requested from, answered or not
+	ReviewRounds       int             `json:"review_rounds,omitempty"`       // Number of review iterations (e.g. Gerrit patch sets), 0 if unknown
+	Timeline           []TimelineEvent `json:"timeline,omitempty"`            // Chronological PR events, where the code host provides them
+}
+
+// FileChange represents a single file changed by a pull request
+type FileChange struct {
+	Path         string `json:"path"`
+	PreviousPath string `json:"previous_path,omitempty"` // Path before a rename
+	Additions    int    `json:"additions"`
+	Deletions    int    `json:"deletions"`
+	Status       string `json:"status,omitempty"` // e.g., "added", "modified", "removed", "renamed"
+}
+
+// Features are characteristics of a pull request derived from its changed files.
+type Features struct {
+	Languages             map[string]int `json:"languages,omitempty"`     // Changed lines per language, by file extension, generated files excluded
+	TestFiles             int            `json:"test_files"`              // Changed test files and test data
+	TestFileShare         float64        `json:"test_file_share"`         // Fraction of changed files that are tests
+	GeneratedFiles        int            `json:"generated_files"`         // Generated, vendored and lock files
+	GeneratedLines        int            `json:"generated_lines"`         // Additions and deletions in generated files
+	ConfigOnly            bool           `json:"config_only"`             // Only configuration files changed, apart from generated ones
+	Directories           int            `json:"directories"`             // Distinct directories touched
+	SizeExcludesGenerated bool           `json:"size_excludes_generated"` // Additions, deletions and changed files leave generated files out
+	GoComplexity          *GoComplexity  `json:"go_complexity,omitempty"` // Only when Go sources were analyzed
+}
+
+// GoComplexity describes how a pull request changes Go code, comparing the base and head
+// versions of its .go files.
+type GoComplexity struct {
+	CyclomaticDelta    int `json:"cyclomatic_delta"`     // Change in the summed cyclomatic complexity of all functions
+	ChangedFunctions   int `json:"changed_functions"`    // Functions and methods added, removed or modified
+	ExportedAPIChanges int `json:"exported_api_changes"` // Exported declarations added, removed or changed
+	NewPackages        int `json:"new_packages"`         // Directories without Go files at the base commit
+	Files              int `json:"files"`                // Go files analyzed
+}
+
+// Review represents a single review (or approval) left on a pull request
+type Review struct {
+	Reviewer    string    `json:"reviewer"`
+	State       string    `json:"state"`        // e.g., "APPROVED", "CHANGES_REQUESTED", "COMMENTED"
+	SubmittedAt time.Time `json:"submitted_at"` // Zero if the code host does not expose a timestamp
+}
+
+// TimelineEvent represents a single entry of a pull request's timeline
+type TimelineEvent struct {
+	Event     string    `json:"event"` // e.g., "review_requested", "committed", "ready_for_review"
+	Actor     string    `json:"actor,omitempty"`
+	Subject   string    `json:"subject,omitempty"` // Requested reviewer or "@org/team" for review request events
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// EarliestReview returns the earliest timestamped review, or nil if no review has a timestamp.
+func EarliestReview(reviews []Review) *time.Time {
+	var earliest *time.Time
+	for _, review := range reviews {
+		if review.SubmittedAt.IsZero() {
+			continue
+		}
+		if earliest == nil || review.SubmittedAt.Before(*earliest) {
+			submittedAt := review.SubmittedAt
+			earliest = &submittedAt
+		}
+	}
+	return earliest
+}
+
+// IsTeam reports whether a requested reviewer is a team rather than a user.
+func IsTeam(reviewer string) bool {
+	return strings.HasPrefix(reviewer, "@")
 }
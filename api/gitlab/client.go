@@ -0,0 +1,365 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+type Client struct {
+	httpClient *http.Client
+	config     *config.GitLabConfig
+}
+
+func NewClient(cfg *config.GitLabConfig) *Client {
+	return &Client{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		config:     cfg,
+	}
+}
+
+// GetPullRequests fetches merge requests for the configured project and maps them into PrData.
+// The state follows the GitHub vocabulary ("open", "closed", "all"); "closed" includes merged MRs.
+func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int) ([]*github.PrData, error) {
+	if perPage <= 0 {
+		perPage = github.DefaultPerPage
+	}
+	var allPrs []*github.PrData
+	for _, glState := range gitlabStates(state) {
+		query := url.Values{}
+		query.Set("state", glState)
+		query.Set("per_page", strconv.Itoa(perPage))
+		query.Set("order_by", "created_at")
+
+		page := "1"
+		for page != "" {
+			query.Set("page", page)
+			var mrs []mergeRequest
+			resp, err := c.get(ctx, c.projectPath("merge_requests"), query, &mrs)
+			if err != nil {
+				return nil, err
+			}
+
+			for _, mr := range mrs {
+				allPrs = append(allPrs, c.toPrData(ctx, mr))
+			}
+			page = resp.Header.Get("X-Next-Page")
+		}
+	}
+	return allPrs, nil
+}
+
+// toPrData enriches a merge request with diff stats, notes and approvals.
+func (c *Client) toPrData(ctx context.Context, mr mergeRequest) *github.PrData {
+	prData := &github.PrData{
+		Number:    mr.IID,
+		Title:     mr.Title,
+		State:     mr.State,
+		Author:    mr.Author.Username,
+		CreatedAt: mr.CreatedAt,
+		MergedAt:  mr.MergedAt,
+		ClosedAt:  mr.ClosedAt,
+		Labels:    mr.Labels,
+	}
+	for _, r := range mr.Reviewers {
+		prData.RequestedReviewers = append(prData.RequestedReviewers, r.Username)
+	}
+	if mr.State == "opened" {
+		prData.State = "open"
+	} else if mr.State == "locked" {
+		prData.State = "closed"
+	}
+
+	diffs, err := c.listDiffs(ctx, mr.IID)
+	if err != nil {
+		log.Printf("Warning: Could not fetch diffs for MR !%d: %v", mr.IID, err)
+	}
+	prData.ChangedFiles = len(diffs)
+	for _, d := range diffs {
+		additions, deletions := countDiffLines(d.Diff)
+		prData.Additions += additions
+		prData.Deletions += deletions
+		file := github.FileChange{
+			Path:      d.NewPath,
+			Additions: additions,
+			Deletions: deletions,
+			Status:    diffStatus(d),
+		}
+		if file.Status == "renamed" {
+			file.PreviousPath = d.OldPath
+		}
+		prData.Files = append(prData.Files, file)
+	}
+	if mr.DiffRefs == nil && changesGo(prData.Files) {
+		// The list API omits diff refs; they are only needed to read the versions of Go files
+		var detailed mergeRequest
+		if _, err := c.get(ctx, c.projectPath(fmt.Sprintf("merge_requests/%d", mr.IID)), nil, &detailed); err != nil {
+			log.Printf("Warning: Could not fetch diff refs for MR !%d: %v", mr.IID, err)
+		}
+		mr.DiffRefs = detailed.DiffRefs
+	}
+	if mr.DiffRefs != nil {
+		prData.BaseSHA, prData.HeadSHA = mr.DiffRefs.BaseSHA, mr.DiffRefs.HeadSHA
+	}
+
+	notes, err := c.listNotes(ctx, mr.IID)
+	if err != nil {
+		log.Printf("Warning: Could not fetch notes for MR !%d: %v", mr.IID, err)
+	}
+	prData.Reviews = reviewsFromNotes(notes, mr.Author.Username)
+
+	var approved approvals
+	if _, err := c.get(ctx, c.projectPath(fmt.Sprintf("merge_requests/%d/approvals", mr.IID)), nil, &approved); err != nil {
+		log.Printf("Warning: Could not fetch approvals for MR !%d: %v", mr.IID, err)
+	}
+	for _, approver := range approved.ApprovedBy {
+		if !hasApproval(prData.Reviews, approver.User.Username) {
+			// The approvals API carries no timestamp, so this review can't contribute to FirstReviewedAt.
+			prData.Reviews = append(prData.Reviews, github.Review{
+				Reviewer: approver.User.Username,
+				State:    "APPROVED",
+			})
+		}
+	}
+
+	prData.FirstReviewedAt = github.EarliestReview(prData.Reviews)
+	return prData
+}
+
+// listDiffs pages through the per-file diffs of a merge request.
+func (c *Client) listDiffs(ctx context.Context, iid int) ([]diff, error) {
+	var allDiffs []diff
+	query := url.Values{"per_page": {"100"}}
+	page := "1"
+	for page != "" {
+		query.Set("page", page)
+		var diffs []diff
+		resp, err := c.get(ctx, c.projectPath(fmt.Sprintf("merge_requests/%d/diffs", iid)), query, &diffs)
+		if err != nil {
+			return allDiffs, err
+		}
+		allDiffs = append(allDiffs, diffs...)
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return allDiffs, nil
+}
+
+// listNotes pages through the notes of a merge request, oldest first.
+func (c *Client) listNotes(ctx context.Context, iid int) ([]note, error) {
+	var allNotes []note
+	query := url.Values{"sort": {"asc"}, "order_by": {"created_at"}, "per_page": {"100"}}
+	page := "1"
+	for page != "" {
+		query.Set("page", page)
+		var notes []note
+		resp, err := c.get(ctx, c.projectPath(fmt.Sprintf("merge_requests/%d/notes", iid)), query, &notes)
+		if err != nil {
+			return allNotes, err
+		}
+		allNotes = append(allNotes, notes...)
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return allNotes, nil
+}
+
+// GetFile reads a file at ref, or from the default branch when ref is empty.
+func (c *Client) GetFile(ctx context.Context, path, ref string) ([]byte, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	resp, err := c.do(ctx, c.projectPath("repository/files/"+url.PathEscape(path)+"/raw"), url.Values{"ref": {ref}})
+	var status *statusError
+	if errors.As(err, &status) && status.Code == http.StatusNotFound {
+		return nil, fmt.Errorf("%s: %w", path, github.ErrFileNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// ListDirectory returns the names of the entries of dir at ref, or on the default branch when
+// ref is empty.
+func (c *Client) ListDirectory(ctx context.Context, dir, ref string) ([]string, error) {
+	if ref == "" {
+		ref = "HEAD"
+	}
+	if dir == "." {
+		dir = ""
+	}
+	var names []string
+	query := url.Values{"path": {dir}, "ref": {ref}, "per_page": {"100"}}
+	page := "1"
+	for page != "" {
+		query.Set("page", page)
+		var entries []treeEntry
+		resp, err := c.get(ctx, c.projectPath("repository/tree"), query, &entries)
+		var status *statusError
+		if errors.As(err, &status) && status.Code == http.StatusNotFound {
+			return nil, fmt.Errorf("%s: %w", dir, github.ErrFileNotFound)
+		}
+		if err != nil {
+			return names, err
+		}
+		for _, e := range entries {
+			names = append(names, e.Name)
+		}
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return names, nil
+}
+
+// get performs an authenticated GET against the GitLab REST API and decodes the JSON body into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v any) (*http.Response, error) {
+	resp, err := c.do(ctx, path, query)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return nil, fmt.Errorf("decoding %s: %w", path, err)
+	}
+	return resp, nil
+}
+
+// do performs an authenticated GET against the GitLab REST API; the caller closes the body of successful responses.
+func (c *Client) do(ctx context.Context, path string, query url.Values) (*http.Response, error) {
+	u := strings.TrimRight(c.config.BaseURL, "/") + "/api/v4/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("PRIVATE-TOKEN", c.config.Token)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &statusError{Path: path, Status: resp.Status, Code: resp.StatusCode}
+	}
+	return resp, nil
+}
+
+// statusError reports an unexpected HTTP status from the GitLab API.
+type statusError struct {
+	Path   string
+	Status string
+	Code   int
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("GET %s: unexpected status %s", e.Path, e.Status)
+}
+
+func (c *Client) projectPath(suffix string) string {
+	return "projects/" + url.PathEscape(c.config.Project) + "/" + suffix
+}
+
+// gitlabStates translates a GitHub-style state filter into GitLab merge request states.
+func gitlabStates(state string) []string {
+	switch state {
+	case "open":
+		return []string{"opened"}
+	case "closed":
+		return []string{"merged", "closed"}
+	default:
+		return []string{"all"}
+	}
+}
+
+// reviewsFromNotes treats approval system notes and comments by anyone but the author as reviews.
+func reviewsFromNotes(notes []note, author string) []github.Review {
+	var reviews []github.Review
+	for _, n := range notes {
+		if n.Author.Username == author {
+			continue
+		}
+		state := "COMMENTED"
+		if n.System {
+			switch {
+			case strings.HasPrefix(n.Body, "approved this merge request"):
+				state = "APPROVED"
+			case strings.HasPrefix(n.Body, "requested changes"):
+				state = "CHANGES_REQUESTED"
+			default:
+				continue // Other system notes (pushes, label changes, ...) aren't reviews
+			}
+		}
+		reviews = append(reviews, github.Review{
+			Reviewer:    n.Author.Username,
+			State:       state,
+			SubmittedAt: n.CreatedAt,
+		})
+	}
+	return reviews
+}
+
+// changesGo reports whether any of files is a Go source file.
+func changesGo(files []github.FileChange) bool {
+	for _, f := range files {
+		if strings.HasSuffix(f.Path, ".go") || strings.HasSuffix(f.PreviousPath, ".go") {
+			return true
+		}
+	}
+	return false
+}
+
+func hasApproval(reviews []github.Review, reviewer string) bool {
+	for _, review := range reviews {
+		if review.Reviewer == reviewer && review.State == "APPROVED" {
+			return true
+		}
+	}
+	return false
+}
+
+// diffStatus maps a GitLab diff onto GitHub's file status vocabulary.
+func diffStatus(d diff) string {
+	switch {
+	case d.NewFile:
+		return "added"
+	case d.DeletedFile:
+		return "removed"
+	case d.OldPath != "" && d.NewPath != d.OldPath:
+		return "renamed"
+	default:
+		return "modified"
+	}
+}
+
+// countDiffLines counts added and removed lines in a unified diff hunk body.
+func countDiffLines(diff string) (additions, deletions int) {
+	inHunk := false
+	for _, line := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+			// File headers before the first hunk; GitLab's diffs usually start at the hunk
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
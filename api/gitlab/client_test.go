@@ -0,0 +1,177 @@
+package gitlab_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlab"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// setupMockGitLabServer creates a stand-in for the GitLab v4 API serving two merge requests.
+func setupMockGitLabServer(t *testing.T, created time.Time) *httptest.Server {
+	writeJSON := func(w http.ResponseWriter, v any) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(v)
+	}
+
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("PRIVATE-TOKEN") != "dummy_token" {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/group%2Fproject/merge_requests":
+			if r.URL.Query().Get("state") != "merged" {
+				io.WriteString(w, "[]")
+				return
+			}
+			writeJSON(w, []map[string]any{
+				{
+					"iid":        7,
+					"title":      "Add caching",
+					"state":      "merged",
+					"author":     map[string]any{"username": "alice"},
+					"created_at": created,
+					"merged_at":  created.Add(30 * time.Hour),
+					"closed_at":  nil,
+					"labels":     []string{"backend"},
+					"reviewers":  []map[string]any{{"username": "bob"}, {"username": "carol"}},
+				},
+			})
+		case "/api/v4/projects/group%2Fproject/merge_requests/7":
+			writeJSON(w, map[string]any{
+				"iid":       7,
+				"diff_refs": map[string]any{"base_sha": "b4se", "head_sha": "he4d", "start_sha": "st4rt"},
+			})
+		case "/api/v4/projects/group%2Fproject/merge_requests/7/diffs":
+			writeJSON(w, []map[string]any{
+				// Removed "-- note" and added "++ more" lines start like file headers but are content
+				{"new_path": "cache.go", "diff": "@@ -1,3 +1,4 @@\n package cache\n-old\n--- note\n+new\n+more\n+++ more\n"},
+				{"new_path": "cache_test.go", "new_file": true, "diff": "@@ -0,0 +1 @@\n+package cache\n"},
+			})
+		case "/api/v4/projects/group%2Fproject/merge_requests/7/notes":
+			// Two pages, so that the approval is only found by following X-Next-Page
+			if r.URL.Query().Get("page") == "2" {
+				writeJSON(w, []map[string]any{
+					{"body": "approved this merge request", "author": map[string]any{"username": "bob"}, "created_at": created.Add(6 * time.Hour), "system": true},
+				})
+				return
+			}
+			w.Header().Set("X-Next-Page", "2")
+			writeJSON(w, []map[string]any{
+				{"body": "added 1 commit", "author": map[string]any{"username": "alice"}, "created_at": created.Add(time.Hour), "system": true},
+				{"body": "Looks reasonable, one nit", "author": map[string]any{"username": "bob"}, "created_at": created.Add(4 * time.Hour), "system": false},
+			})
+		case "/api/v4/projects/group%2Fproject/merge_requests/7/approvals":
+			writeJSON(w, map[string]any{
+				"approved_by": []map[string]any{
+					{"user": map[string]any{"username": "bob"}},
+					{"user": map[string]any{"username": "carol"}},
+				},
+			})
+		case "/api/v4/projects/group%2Fproject/repository/files/.github%2FCODEOWNERS/raw":
+			io.WriteString(w, "* @group/maintainers\n")
+		default:
+			http.Error(w, "Not Found", http.StatusNotFound)
+		}
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(handler))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestGetPullRequests(t *testing.T) {
+	created := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	server := setupMockGitLabServer(t, created)
+
+	client := gitlab.NewClient(&config.GitLabConfig{
+		Token:   "dummy_token",
+		BaseURL: server.URL,
+		Project: "group/project",
+	})
+
+	prs, err := client.GetPullRequests(context.Background(), "closed", 20)
+	if err != nil {
+		t.Fatalf("GetPullRequests failed: %v", err)
+	}
+	if len(prs) != 1 {
+		t.Fatalf("Expected 1 merge request, got %d", len(prs))
+	}
+
+	pr := prs[0]
+	if pr.Number != 7 || pr.Author != "alice" || pr.State != "merged" {
+		t.Errorf("Unexpected MR identity: %+v", pr)
+	}
+	if pr.MergedAt == nil || !pr.MergedAt.Equal(created.Add(30*time.Hour)) {
+		t.Errorf("Expected MergedAt %v, got %v", created.Add(30*time.Hour), pr.MergedAt)
+	}
+	if pr.ClosedAt != nil {
+		t.Errorf("Expected ClosedAt to be nil, got %v", pr.ClosedAt)
+	}
+	if pr.Additions != 4 || pr.Deletions != 2 || pr.ChangedFiles != 2 {
+		t.Errorf("Expected size +4 / -2 in 2 files, got +%d / -%d in %d files", pr.Additions, pr.Deletions, pr.ChangedFiles)
+	}
+	if pr.FirstReviewedAt == nil || !pr.FirstReviewedAt.Equal(created.Add(4*time.Hour)) {
+		t.Errorf("Expected FirstReviewedAt %v, got %v", created.Add(4*time.Hour), pr.FirstReviewedAt)
+	}
+	if len(pr.Reviews) != 3 {
+		t.Fatalf("Expected 3 reviews (comment, approval note, timestamp-less approval), got %+v", pr.Reviews)
+	}
+	if pr.Reviews[2].Reviewer != "carol" || pr.Reviews[2].State != "APPROVED" || !pr.Reviews[2].SubmittedAt.IsZero() {
+		t.Errorf("Expected a timestamp-less approval from carol, got %+v", pr.Reviews[2])
+	}
+	if len(pr.Files) != 2 || pr.Files[1].Path != "cache_test.go" || pr.Files[1].Status != "added" || pr.Files[0].Additions != 3 {
+		t.Errorf("Unexpected files %+v", pr.Files)
+	}
+	if len(pr.RequestedReviewers) != 2 || pr.RequestedReviewers[0] != "bob" {
+		t.Errorf("Expected requested reviewers [bob carol], got %v", pr.RequestedReviewers)
+	}
+	if len(pr.Labels) != 1 || pr.Labels[0] != "backend" {
+		t.Errorf("Expected labels [backend], got %v", pr.Labels)
+	}
+	if pr.BaseSHA != "b4se" || pr.HeadSHA != "he4d" {
+		t.Errorf("Expected base and head from the diff refs, got %q and %q", pr.BaseSHA, pr.HeadSHA)
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	server := setupMockGitLabServer(t, time.Now())
+
+	client := gitlab.NewClient(&config.GitLabConfig{
+		Token:   "dummy_token",
+		BaseURL: server.URL,
+		Project: "group/project",
+	})
+
+	content, err := client.GetFile(context.Background(), ".github/CODEOWNERS", "")
+	if err != nil || string(content) != "* @group/maintainers\n" {
+		t.Errorf("Expected CODEOWNERS content, got %q (err %v)", content, err)
+	}
+	if _, err := client.GetFile(context.Background(), "CODEOWNERS", ""); !errors.Is(err, github.ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound for a missing file, got %v", err)
+	}
+}
+
+func TestGetPullRequests_Unauthorized(t *testing.T) {
+	server := setupMockGitLabServer(t, time.Now())
+
+	client := gitlab.NewClient(&config.GitLabConfig{
+		Token:   "wrong_token",
+		BaseURL: server.URL,
+		Project: "group/project",
+	})
+
+	if _, err := client.GetPullRequests(context.Background(), "closed", 20); err == nil {
+		t.Fatal("Expected an error for an unauthorized request, but got none")
+	}
+}
@@ -0,0 +1,58 @@
+package gitlab
+
+import "time"
+
+// user is the subset of a GitLab user object we rely on.
+type user struct {
+	Username string `json:"username"`
+}
+
+// mergeRequest mirrors the fields of the GitLab merge request API we map into PrData.
+type mergeRequest struct {
+	IID       int        `json:"iid"`
+	Title     string     `json:"title"`
+	State     string     `json:"state"` // "opened", "closed", "merged" or "locked"
+	Author    user       `json:"author"`
+	CreatedAt time.Time  `json:"created_at"`
+	MergedAt  *time.Time `json:"merged_at"`
+	ClosedAt  *time.Time `json:"closed_at"`
+	Labels    []string   `json:"labels"`
+	Reviewers []user     `json:"reviewers"`
+	DiffRefs  *diffRefs  `json:"diff_refs"` // Only returned for single merge requests
+}
+
+// diffRefs are the commits a merge request's diff is computed between; BaseSHA is the merge base.
+type diffRefs struct {
+	BaseSHA string `json:"base_sha"`
+	HeadSHA string `json:"head_sha"`
+}
+
+// diff is a single file entry returned by the merge request diffs API.
+type diff struct {
+	Diff        string `json:"diff"`
+	NewPath     string `json:"new_path"`
+	OldPath     string `json:"old_path"`
+	NewFile     bool   `json:"new_file"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+// treeEntry is a file or directory returned by the repository tree API.
+type treeEntry struct {
+	Name string `json:"name"`
+	Type string `json:"type"` // "blob" or "tree"
+}
+
+// note is a comment or system event on a merge request.
+type note struct {
+	Body      string    `json:"body"`
+	Author    user      `json:"author"`
+	CreatedAt time.Time `json:"created_at"`
+	System    bool      `json:"system"`
+}
+
+// approvals is the response of the merge request approvals API.
+type approvals struct {
+	ApprovedBy []struct {
+		User user `json:"user"`
+	} `json:"approved_by"`
+}
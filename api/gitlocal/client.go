@@ -0,0 +1,324 @@
+// Package gitlocal reconstructs merged pull requests from the history of a local git repository,
+// for offline analysis of mirrors without API access. Review data is not available from git,
+// so only size and lead-time metrics can be derived.
+package gitlocal
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+const (
+	fieldSep  = "\x1f"
+	recordSep = "\x1e"
+	headerEnd = "\x1d"
+)
+
+// logFormat emits one record per commit: hash, parents, author, author date, commit date,
+// subject and body, followed by the numstat lines git prints after the header.
+const logFormat = recordSep + "%H" + fieldSep + "%P" + fieldSep + "%an" + fieldSep + "%aI" + fieldSep + "%cI" +
+	fieldSep + "%s" + fieldSep + "%b" + headerEnd
+
+var (
+	// githubMerge matches GitHub's "Merge pull request #123 from owner/branch" merge commits.
+	githubMerge = regexp.MustCompile(`^Merge pull request #(\d+) from \S+`)
+	// gitlabMerge matches the "See merge request group/project!123" trailer of GitLab merge commits.
+	gitlabMerge = regexp.MustCompile(`See merge request \S*!(\d+)`)
+	// squashSuffix matches the "(#123)" suffix GitHub appends to squash and rebase merges.
+	squashSuffix = regexp.MustCompile(`\s*\(#(\d+)\)\s*$`)
+)
+
+// commit is a single first-parent commit parsed from git log.
+type commit struct {
+	Hash        string
+	Parents     []string
+	Author      string
+	AuthoredAt  time.Time
+	CommittedAt time.Time
+	Subject     string
+	Body        string
+	Additions   int
+	Deletions   int
+	Files       []github.FileChange
+}
+
+// baseAndHead returns the commits a PR's changes go from and to: the first parent and the
+// merged branch for merge commits, or the first parent and the commit itself for squash merges.
+func (cm commit) baseAndHead() (string, string) {
+	if len(cm.Parents) == 0 {
+		return "", cm.Hash
+	}
+	if len(cm.Parents) > 1 {
+		return cm.Parents[0], cm.Parents[1]
+	}
+	return cm.Parents[0], cm.Hash
+}
+
+type Client struct {
+	config *config.GitLocalConfig
+}
+
+func NewClient(cfg *config.GitLocalConfig) *Client {
+	return &Client{config: cfg}
+}
+
+// GetPullRequests reconstructs merged PRs from merge commits and "(#123)" squash commits on the
+// configured branch. History only records merged work, so the "open" state yields nothing and
+// perPage is ignored.
+func (c *Client) GetPullRequests(ctx context.Context, state string, perPage int) ([]*github.PrData, error) {
+	if state == "open" {
+		return nil, nil
+	}
+
+	out, err := c.git(ctx, "log", "--first-parent", "--diff-merges=first-parent", "--numstat", "--format="+logFormat, c.config.Branch)
+	if err != nil {
+		return nil, err
+	}
+	commits, err := parseLog(out)
+	if err != nil {
+		return nil, err
+	}
+
+	var allPrs []*github.PrData
+	for _, cm := range commits {
+		prData := c.toPrData(ctx, cm)
+		if prData != nil {
+			allPrs = append(allPrs, prData)
+		}
+	}
+	return allPrs, nil
+}
+
+// toPrData returns nil for commits that don't reference a pull request.
+func (c *Client) toPrData(ctx context.Context, cm commit) *github.PrData {
+	mergedAt := cm.CommittedAt
+	prData := &github.PrData{
+		State:        "merged",
+		Author:       cm.Author,
+		CreatedAt:    cm.AuthoredAt,
+		MergedAt:     &mergedAt,
+		ClosedAt:     &mergedAt,
+		Additions:    cm.Additions,
+		Deletions:    cm.Deletions,
+		ChangedFiles: len(cm.Files),
+		Files:        cm.Files,
+	}
+	prData.BaseSHA, prData.HeadSHA = cm.baseAndHead()
+
+	title, _, _ := strings.Cut(strings.TrimSpace(cm.Body), "\n")
+	if m := githubMerge.FindStringSubmatch(cm.Subject); m != nil && len(cm.Parents) > 1 {
+		prData.Number, _ = strconv.Atoi(m[1])
+		prData.Title = title
+	} else if m := gitlabMerge.FindStringSubmatch(cm.Body); m != nil && len(cm.Parents) > 1 {
+		prData.Number, _ = strconv.Atoi(m[1])
+		prData.Title = title
+	} else if m := squashSuffix.FindStringSubmatch(cm.Subject); m != nil {
+		// Squash merges keep a single commit; its author date is the best available creation time
+		prData.Number, _ = strconv.Atoi(m[1])
+		prData.Title = squashSuffix.ReplaceAllString(cm.Subject, "")
+		return prData
+	} else {
+		return nil
+	}
+
+	// For merge commits, the PR was opened no later than its branch's first commit, by that commit's author
+	out, err := c.git(ctx, "log", "--reverse", "--format=%an"+fieldSep+"%aI", cm.Parents[0]+".."+cm.Parents[1])
+	if err == nil {
+		first, _, _ := strings.Cut(out, "\n")
+		if author, date, ok := strings.Cut(first, fieldSep); ok {
+			if createdAt, err := time.Parse(time.RFC3339, strings.TrimSpace(date)); err == nil {
+				prData.Author = author
+				prData.CreatedAt = createdAt
+			}
+		}
+	}
+	if prData.Title == "" {
+		prData.Title = cm.Subject
+	}
+	return prData
+}
+
+// BranchDiff describes the commits on the current branch that are not on base as an open PR, so
+// it can be estimated before it is pushed. The title is the subject of the branch's first commit,
+// and the PR counts as created when that commit was authored.
+func (c *Client) BranchDiff(ctx context.Context, base string) (*github.PrData, error) {
+	mergeBase, err := c.git(ctx, "merge-base", base, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	mergeBase = strings.TrimSpace(mergeBase)
+	numstat, err := c.git(ctx, "diff", "--numstat", mergeBase, "HEAD")
+	if err != nil {
+		return nil, err
+	}
+	head, err := c.git(ctx, "rev-parse", "HEAD")
+	if err != nil {
+		return nil, err
+	}
+
+	prData := &github.PrData{
+		Title:     "Local changes",
+		State:     "open",
+		CreatedAt: time.Now(),
+		BaseSHA:   mergeBase,
+		HeadSHA:   strings.TrimSpace(head),
+	}
+	prData.Additions, prData.Deletions, prData.Files = parseNumstat(numstat)
+	prData.ChangedFiles = len(prData.Files)
+
+	out, err := c.git(ctx, "log", "--reverse", "--format=%an"+fieldSep+"%aI"+fieldSep+"%s", mergeBase+"..HEAD")
+	if err != nil {
+		return nil, err
+	}
+	first, _, _ := strings.Cut(out, "\n")
+	if fields := strings.SplitN(first, fieldSep, 3); len(fields) == 3 {
+		prData.Author = fields[0]
+		if createdAt, err := time.Parse(time.RFC3339, fields[1]); err == nil {
+			prData.CreatedAt = createdAt
+		}
+		prData.Title = fields[2]
+	}
+	return prData, nil
+}
+
+// DefaultBase returns the branch the remote "origin" points HEAD to, e.g. "origin/main",
+// falling back to "main" when there is none.
+func (c *Client) DefaultBase(ctx context.Context) string {
+	out, err := c.git(ctx, "rev-parse", "--abbrev-ref", "origin/HEAD")
+	if ref := strings.TrimSpace(out); err == nil && ref != "" && ref != "origin/HEAD" {
+		return ref
+	}
+	return "main"
+}
+
+// GetFile reads a file at ref, or from the configured branch when ref is empty.
+func (c *Client) GetFile(ctx context.Context, path, ref string) ([]byte, error) {
+	if ref == "" {
+		ref = c.config.Branch
+	}
+	out, err := c.git(ctx, "show", ref+":"+path)
+	if err != nil && (strings.Contains(err.Error(), "does not exist in") || strings.Contains(err.Error(), "exists on disk, but not in")) {
+		return nil, fmt.Errorf("%s: %w", path, github.ErrFileNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(out), nil
+}
+
+// ListDirectory returns the names of the entries of dir at ref, or on the configured branch
+// when ref is empty.
+func (c *Client) ListDirectory(ctx context.Context, dir, ref string) ([]string, error) {
+	if ref == "" {
+		ref = c.config.Branch
+	}
+	args := []string{"ls-tree", "--name-only", ref}
+	if dir != "" && dir != "." {
+		args = append(args, "--", strings.TrimSuffix(dir, "/")+"/")
+	}
+	out, err := c.git(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+	var names []string
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		if line != "" {
+			names = append(names, path.Base(line))
+		}
+	}
+	if len(names) == 0 {
+		// git has no empty directories, so an empty listing means dir doesn't exist at ref
+		return nil, fmt.Errorf("%s: %w", dir, github.ErrFileNotFound)
+	}
+	return names, nil
+}
+
+// git runs a git subcommand inside the configured repository and returns its stdout.
+func (c *Client) git(ctx context.Context, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", c.config.Path}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %v: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return string(out), nil
+}
+
+// parseLog splits the output of git log with logFormat and --numstat into commits.
+func parseLog(out string) ([]commit, error) {
+	var commits []commit
+	for _, record := range strings.Split(out, recordSep) {
+		if strings.TrimSpace(record) == "" {
+			continue
+		}
+		header, numstat, _ := strings.Cut(record, headerEnd)
+		fields := strings.SplitN(header, fieldSep, 7)
+		if len(fields) != 7 {
+			return nil, fmt.Errorf("unexpected git log record %q", header)
+		}
+
+		cm := commit{
+			Hash:    fields[0],
+			Parents: strings.Fields(fields[1]),
+			Author:  fields[2],
+			Subject: fields[5],
+			Body:    fields[6],
+		}
+		var err error
+		if cm.AuthoredAt, err = time.Parse(time.RFC3339, fields[3]); err != nil {
+			return nil, fmt.Errorf("parsing author date of %s: %w", cm.Hash, err)
+		}
+		if cm.CommittedAt, err = time.Parse(time.RFC3339, fields[4]); err != nil {
+			return nil, fmt.Errorf("parsing commit date of %s: %w", cm.Hash, err)
+		}
+
+		cm.Additions, cm.Deletions, cm.Files = parseNumstat(numstat)
+		commits = append(commits, cm)
+	}
+	return commits, nil
+}
+
+// parseNumstat sums up the lines of git --numstat output and lists the changed files.
+func parseNumstat(numstat string) (additions, deletions int, files []github.FileChange) {
+	for _, line := range strings.Split(numstat, "\n") {
+		parts := strings.SplitN(line, "\t", 3)
+		if len(parts) != 3 {
+			continue
+		}
+		// Binary files report "-" for both counts; they still count as changed files
+		added, _ := strconv.Atoi(parts[0])
+		deleted, _ := strconv.Atoi(parts[1])
+		additions += added
+		deletions += deleted
+		path, previous := numstatPaths(parts[2])
+		files = append(files, github.FileChange{Path: path, PreviousPath: previous, Additions: added, Deletions: deleted})
+	}
+	return additions, deletions, files
+}
+
+// numstatPaths returns the path of a numstat entry, plus the previous path of renames that git
+// prints as "old => new" or "dir/{old => new}/file".
+func numstatPaths(path string) (string, string) {
+	before, rest, ok := strings.Cut(path, "{")
+	if inner, after, found := strings.Cut(rest, "}"); ok && found {
+		if old, renamed, isRename := strings.Cut(inner, " => "); isRename {
+			clean := func(p string) string { return strings.ReplaceAll(before+p+after, "//", "/") }
+			return clean(renamed), clean(old)
+		}
+	}
+	if old, renamed, isRename := strings.Cut(path, " => "); isRename {
+		return renamed, old
+	}
+	return path, ""
+}
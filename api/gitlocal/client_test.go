@@ -0,0 +1,195 @@
+package gitlocal_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlocal"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// setupRepo builds a throwaway repository with one merge-commit PR (#12), one squash PR (#13)
+// and one direct commit that references no PR.
+func setupRepo(t *testing.T, start time.Time) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	run := func(at time.Time, author string, args ...string) { runGit(t, dir, at, author, args...) }
+	write := func(name, content string) { writeFile(t, dir, name, content) }
+
+	run(start, "setup", "init", "-q", "-b", "main")
+	write("README.md", "hello\n")
+	run(start, "setup", "add", ".")
+	run(start, "setup", "commit", "-q", "-m", "Initial commit")
+
+	run(start, "alice", "checkout", "-q", "-b", "feature")
+	write("feature.go", "package feature\n\nfunc A() {}\n")
+	run(start.Add(2*time.Hour), "alice", "add", ".")
+	run(start.Add(2*time.Hour), "alice", "commit", "-q", "-m", "Start feature")
+	write("feature.go", "package feature\n\nfunc A() {}\n\nfunc B() {}\n")
+	run(start.Add(5*time.Hour), "alice", "commit", "-q", "-am", "Finish feature")
+
+	run(start.Add(30*time.Hour), "bob", "checkout", "-q", "main")
+	run(start.Add(30*time.Hour), "bob", "merge", "-q", "--no-ff", "feature", "-m", "Merge pull request #12 from alice/feature", "-m", "Add feature package")
+
+	write("README.md", "hello world\n")
+	run(start.Add(40*time.Hour), "carol", "commit", "-q", "-am", "Fix typo in README (#13)")
+
+	write("NOTES.md", "direct push\n")
+	run(start.Add(50*time.Hour), "dave", "add", ".")
+	run(start.Add(50*time.Hour), "dave", "commit", "-q", "-m", "Add notes")
+	return dir
+}
+
+// runGit runs git in dir with fixed author and commit dates.
+func runGit(t *testing.T, dir string, at time.Time, author string, args ...string) {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME="+author, "GIT_AUTHOR_EMAIL="+author+"@example.com",
+		"GIT_COMMITTER_NAME=merger", "GIT_COMMITTER_EMAIL=merger@example.com",
+		"GIT_AUTHOR_DATE="+at.Format(time.RFC3339), "GIT_COMMITTER_DATE="+at.Format(time.RFC3339),
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) {
+	if err := os.MkdirAll(filepath.Dir(filepath.Join(dir, name)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetPullRequests(t *testing.T) {
+	start := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	dir := setupRepo(t, start)
+
+	client := gitlocal.NewClient(&config.GitLocalConfig{Path: dir, Branch: "main"})
+	prs, err := client.GetPullRequests(context.Background(), "closed", 100)
+	if err != nil {
+		t.Fatalf("GetPullRequests failed: %v", err)
+	}
+	if len(prs) != 2 {
+		t.Fatalf("Expected 2 reconstructed PRs, got %d", len(prs))
+	}
+
+	squash := prs[0]
+	if squash.Number != 13 || squash.Title != "Fix typo in README" || squash.Author != "carol" {
+		t.Errorf("Unexpected squash PR: %+v", squash)
+	}
+	if squash.Additions != 1 || squash.Deletions != 1 || squash.ChangedFiles != 1 {
+		t.Errorf("Expected squash size +1 / -1 in 1 file, got +%d / -%d in %d files", squash.Additions, squash.Deletions, squash.ChangedFiles)
+	}
+
+	merge := prs[1]
+	if merge.Number != 12 || merge.Title != "Add feature package" || merge.Author != "alice" {
+		t.Errorf("Unexpected merge PR: %+v", merge)
+	}
+	if !merge.CreatedAt.Equal(start.Add(2 * time.Hour)) {
+		t.Errorf("Expected CreatedAt at the branch's first commit %v, got %v", start.Add(2*time.Hour), merge.CreatedAt)
+	}
+	if merge.MergedAt == nil || !merge.MergedAt.Equal(start.Add(30*time.Hour)) {
+		t.Errorf("Expected MergedAt %v, got %v", start.Add(30*time.Hour), merge.MergedAt)
+	}
+	if merge.Additions != 5 || merge.Deletions != 0 || merge.ChangedFiles != 1 {
+		t.Errorf("Expected merge size +5 / -0 in 1 file, got +%d / -%d in %d files", merge.Additions, merge.Deletions, merge.ChangedFiles)
+	}
+	if len(merge.Files) != 1 || merge.Files[0].Path != "feature.go" || merge.Files[0].Additions != 5 {
+		t.Errorf("Expected merge to change feature.go with +5, got %+v", merge.Files)
+	}
+	if merge.BaseSHA == "" || merge.HeadSHA == "" || merge.BaseSHA == squash.BaseSHA {
+		t.Errorf("Expected base and head commits of the merged branch, got %q..%q", merge.BaseSHA, merge.HeadSHA)
+	}
+	if merge.FirstReviewedAt != nil {
+		t.Errorf("Expected no review data from git history, got %v", merge.FirstReviewedAt)
+	}
+}
+
+func TestGetFile(t *testing.T) {
+	dir := setupRepo(t, time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC))
+
+	client := gitlocal.NewClient(&config.GitLocalConfig{Path: dir, Branch: "main"})
+	content, err := client.GetFile(context.Background(), "README.md", "")
+	if err != nil || string(content) != "hello world\n" {
+		t.Errorf("Expected README.md from main, got %q (err %v)", content, err)
+	}
+	if content, err := client.GetFile(context.Background(), "README.md", "main~2"); err != nil || string(content) != "hello\n" {
+		t.Errorf("Expected README.md before the typo fix, got %q (err %v)", content, err)
+	}
+	if _, err := client.GetFile(context.Background(), "missing.md", ""); !errors.Is(err, github.ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound for a missing file, got %v", err)
+	}
+}
+
+func TestListDirectory(t *testing.T) {
+	dir := setupRepo(t, time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC))
+	writeFile(t, dir, "cache/cache.go", "package cache\n")
+	runGit(t, dir, time.Date(2025, 7, 5, 9, 0, 0, 0, time.UTC), "erin", "add", ".")
+	runGit(t, dir, time.Date(2025, 7, 5, 9, 0, 0, 0, time.UTC), "erin", "commit", "-q", "-m", "Add cache")
+
+	client := gitlocal.NewClient(&config.GitLocalConfig{Path: dir, Branch: "main"})
+	if names, err := client.ListDirectory(context.Background(), ".", "main~1"); err != nil || strings.Join(names, ",") != "NOTES.md,README.md,feature.go" {
+		t.Errorf("Expected the root before the cache was added, got %v (err %v)", names, err)
+	}
+	if names, err := client.ListDirectory(context.Background(), "cache", ""); err != nil || strings.Join(names, ",") != "cache.go" {
+		t.Errorf("Expected cache.go in cache, got %v (err %v)", names, err)
+	}
+	if _, err := client.ListDirectory(context.Background(), "cache", "main~1"); !errors.Is(err, github.ErrFileNotFound) {
+		t.Errorf("Expected ErrFileNotFound before the cache was added, got %v", err)
+	}
+}
+
+func TestBranchDiff(t *testing.T) {
+	start := time.Date(2025, 7, 1, 9, 0, 0, 0, time.UTC)
+	dir := setupRepo(t, start)
+
+	at := start.Add(60 * time.Hour)
+	runGit(t, dir, at, "erin", "checkout", "-q", "-b", "topic")
+	writeFile(t, dir, "cache/cache.go", "package cache\n\nfunc Get() {}\n")
+	runGit(t, dir, at, "erin", "add", ".")
+	runGit(t, dir, at, "erin", "commit", "-q", "-m", "Add cache")
+	writeFile(t, dir, "README.md", "hello cache\n")
+	runGit(t, dir, at.Add(time.Hour), "erin", "commit", "-q", "-am", "Document cache")
+	// Commits on main after branching are not part of the change
+	runGit(t, dir, at, "erin", "checkout", "-q", "main")
+	writeFile(t, dir, "NOTES.md", "more notes\n")
+	runGit(t, dir, at.Add(2*time.Hour), "dave", "commit", "-q", "-am", "Update notes")
+	runGit(t, dir, at, "erin", "checkout", "-q", "topic")
+
+	client := gitlocal.NewClient(&config.GitLocalConfig{Path: dir, Branch: "HEAD"})
+	if base := client.DefaultBase(context.Background()); base != "main" {
+		t.Errorf("Expected main as the default base without a remote, got %q", base)
+	}
+	pr, err := client.BranchDiff(context.Background(), "main")
+	if err != nil {
+		t.Fatalf("BranchDiff failed: %v", err)
+	}
+	if pr.Title != "Add cache" || pr.Author != "erin" || !pr.CreatedAt.Equal(at) || pr.State != "open" {
+		t.Errorf("Unexpected local PR: %+v", pr)
+	}
+	if pr.Additions != 4 || pr.Deletions != 1 || pr.ChangedFiles != 2 || pr.Files[1].Path != "cache/cache.go" {
+		t.Errorf("Expected +4 / -1 in README.md and cache/cache.go, got +%d / -%d in %+v", pr.Additions, pr.Deletions, pr.Files)
+	}
+	if pr.BaseSHA == "" || pr.HeadSHA == "" || pr.BaseSHA == pr.HeadSHA {
+		t.Errorf("Expected distinct base and head commits, got %q..%q", pr.BaseSHA, pr.HeadSHA)
+	}
+}
+
+func TestGetPullRequests_OpenIsEmpty(t *testing.T) {
+	client := gitlocal.NewClient(&config.GitLocalConfig{Path: t.TempDir(), Branch: "main"})
+	prs, err := client.GetPullRequests(context.Background(), "open", 100)
+	if err != nil || len(prs) != 0 {
+		t.Errorf("Expected no open PRs from git history, got %v (err %v)", prs, err)
+	}
+}
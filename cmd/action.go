@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/actions"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// runAction estimates the PR that triggered a GitHub Actions workflow, writing a job
+// summary, step outputs and optionally an ETA label.
+func runAction(args []string) error {
+	fs := flag.NewFlagSet("action", flag.ExitOnError)
+	input := fs.String("input", "", "Read historical PRs from a JSONL dataset instead of the GitHub API")
+	label := fs.Bool("label", false, "Label the PR with its expected time to first review, e.g. review-eta:1d")
+	labelPrefix := fs.String("label-prefix", "review-eta:", "Prefix of the ETA label; other labels with this prefix are removed")
+	historySize := fs.Int("history", 200, "Number of recently merged PRs to fetch as history; 0 fetches every closed PR")
+	fs.Parse(args)
+
+	cfg, err := config.LoadActionsConfig()
+	if err != nil {
+		return fmt.Errorf("loading Actions environment: %w", err)
+	}
+	payload, err := os.ReadFile(cfg.EventPath)
+	if err != nil {
+		return fmt.Errorf("reading event payload: %w", err)
+	}
+	event, err := github.ParsePullRequestEvent(payload)
+	if err != nil {
+		return fmt.Errorf("parsing event payload: %w", err)
+	}
+
+	ctx := context.Background()
+	client := github.NewClient(cfg.GitHub)
+	var prs []*github.PrData
+	if *input != "" {
+		prs, _, err = loadPrs(ctx, *input, "closed")
+	} else {
+		if *historySize > 0 {
+			log.Printf("Fetching the last %d merged pull requests for %s/%s...", *historySize, cfg.GitHub.Owner, cfg.GitHub.Repo)
+			prs, err = client.GetMergedPullRequests(ctx, *historySize)
+		} else {
+			log.Printf("Fetching closed pull requests for %s/%s...", cfg.GitHub.Owner, cfg.GitHub.Repo)
+			prs, err = client.GetPullRequests(ctx, "closed", 100)
+		}
+		if err == nil {
+			err = applyFeatures(ctx, client, prs)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	var history []*metrics.PrMetrics
+	for _, pr := range prs {
+		history = append(history, metrics.CalculateMetrics(pr))
+	}
+	opts, err := estimateOptions()
+	if err != nil {
+		return err
+	}
+	pr := metrics.CalculateMetrics(event.PrData())
+	estimate := metrics.EstimateForPrWithOptions(history, pr, opts)
+	markdown := report.EstimateMarkdown(pr, estimate)
+	fmt.Println(markdown)
+
+	if cfg.StepSummary != "" {
+		if err := actions.AppendSummary(cfg.StepSummary, markdown); err != nil {
+			return fmt.Errorf("writing job summary: %w", err)
+		}
+	}
+
+	outputs := map[string]string{
+		"size-bucket": estimate.SizeBucket,
+		"based-on":    estimate.BasedOn,
+		"estimate":    markdown,
+	}
+	if e := estimate.TimeToFirstReview; e.SampleCount > 0 {
+		outputs["first-review-p50-hours"] = strconv.FormatFloat(e.P50.Hours(), 'f', 1, 64)
+		outputs["first-review-p90-hours"] = strconv.FormatFloat(e.P90.Hours(), 'f', 1, 64)
+		outputs["eta-label"] = actions.ETALabel(*labelPrefix, e.P50)
+	}
+	if e := estimate.TimeToMerge; e.SampleCount > 0 {
+		outputs["merge-p50-hours"] = strconv.FormatFloat(e.P50.Hours(), 'f', 1, 64)
+		outputs["merge-p90-hours"] = strconv.FormatFloat(e.P90.Hours(), 'f', 1, 64)
+	}
+	if cfg.Output != "" {
+		if err := actions.SetOutputs(cfg.Output, outputs); err != nil {
+			return fmt.Errorf("writing step outputs: %w", err)
+		}
+	}
+
+	if *label && outputs["eta-label"] != "" {
+		if err := client.ReplaceLabel(ctx, pr.Number, *labelPrefix, outputs["eta-label"]); err != nil {
+			return fmt.Errorf("labelling PR #%d: %w", pr.Number, err)
+		}
+		log.Printf("Labelled PR #%d with %s", pr.Number, outputs["eta-label"])
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runAnomalies reports shifts in review latency over time and PRs with unusual durations for their size.
+func runAnomalies(args []string) error {
+	fs := flag.NewFlagSet("anomalies", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	opts := metrics.DefaultAnomalyOptions
+	fs.Float64Var(&opts.ChangeThreshold, "threshold", opts.ChangeThreshold, "Normalized CUSUM statistic above which a shift is reported")
+	fs.IntVar(&opts.MinSegment, "min-segment", opts.MinSegment, "Minimum number of PRs before and after a shift")
+	fs.Float64Var(&opts.OutlierScore, "score", opts.OutlierScore, "Robust z-score above which a PR is reported as an outlier")
+	fs.Parse(args)
+
+	prs, _, err := loadPrs(context.Background(), *input, "closed")
+	if err != nil {
+		return err
+	}
+	var allMetrics []*metrics.PrMetrics
+	for _, pr := range prs {
+		allMetrics = append(allMetrics, metrics.CalculateMetrics(pr))
+	}
+
+	for _, m := range []struct {
+		name     string
+		selector func(*metrics.PrMetrics) time.Duration
+	}{
+		{"Time to First Review", metrics.FirstReviewSelector},
+		{"Time to Merge", metrics.MergeSelector},
+	} {
+		fmt.Printf("\n--- %s: Change Points ---\n", m.name)
+		points := metrics.DetectChangePoints(allMetrics, m.selector, opts)
+		if len(points) == 0 {
+			fmt.Println("No significant shifts.")
+		}
+		for _, p := range points {
+			fmt.Printf("%s: median %s -> %s (%+.0f%%, %d PRs before, %d after, significance %.2f)\n",
+				p.At.Format("2006-01-02"), report.HumanDuration(p.Before), report.HumanDuration(p.After),
+				p.Change*100, p.BeforeCount, p.AfterCount, p.Significance)
+		}
+
+		fmt.Printf("\n--- %s: Outliers ---\n", m.name)
+		outliers := metrics.DetectOutliers(allMetrics, m.selector, opts)
+		if len(outliers) == 0 {
+			fmt.Println("No outliers.")
+		}
+		for _, o := range outliers {
+			fmt.Printf("PR #%d %s (%s): %s vs. median %s of %s (%.1fx, score %+.1f)\n",
+				o.Pr.Number, o.Pr.Title, o.At.Format("2006-01-02"), report.HumanDuration(o.Duration),
+				report.HumanDuration(o.Median), o.BasedOn, float64(o.Duration)/float64(o.Median), o.Score)
+		}
+	}
+	return nil
+}
@@ -2,31 +2,286 @@ package cmd
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"log"
+	"os"
+	"strings"
+	"time"
 
+	"github.com/sushant-115/pr-effort-estimator/api"
+	"github.com/sushant-115/pr-effort-estimator/api/azuredevops"
+	"github.com/sushant-115/pr-effort-estimator/api/bitbucket"
+	"github.com/sushant-115/pr-effort-estimator/api/gerrit"
 	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlab"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlocal"
+	"github.com/sushant-115/pr-effort-estimator/internal/complexity"
+	"github.com/sushant-115/pr-effort-estimator/internal/dataset"
+	"github.com/sushant-115/pr-effort-estimator/internal/features"
 	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
 	"github.com/sushant-115/pr-effort-estimator/pkg/config"
 )
 
+// Run dispatches to the subcommand named by the first argument, defaulting to "analyze".
 func Run() {
-	cfg, err := config.LoadGitHubConfig()
+	command, args := "analyze", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	var err error
+	switch command {
+	case "analyze":
+		err = runAnalyze(args)
+	case "estimate":
+		err = runEstimate(args)
+	case "export":
+		err = runExport(args)
+	case "serve":
+		err = runServe(args)
+	case "action":
+		err = runAction(args)
+	case "trend":
+		err = runTrend(args)
+	case "anomalies":
+		err = runAnomalies(args)
+	case "sla":
+		err = runSLA(args)
+	case "stale":
+		err = runStale(args)
+	case "reviewers":
+		err = runReviewers(args)
+	case "recommend":
+		err = runRecommend(args)
+	case "owners":
+		err = runOwners(args)
+	case "forecast":
+		err = runForecast(args)
+	case "groups":
+		err = runGroups(args)
+	default:
+		err = fmt.Errorf("unknown command %q (expected analyze, estimate, export, serve, action, trend, anomalies, sla, stale, reviewers, recommend, owners, forecast or groups)", command)
+	}
 	if err != nil {
-		log.Fatalf("Error loading GitHub configuration: %v", err)
+		log.Fatalf("Error running %s: %v", command, err)
 	}
+}
 
-	ghClient := github.NewClient(cfg)
-	ctx := context.Background()
+// runAnalyze prints per-PR metrics followed by aggregated statistics and estimates.
+func runAnalyze(args []string) error {
+	fs := flag.NewFlagSet("analyze", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	state := fs.String("state", "closed", "Pull request state to fetch: open, closed or all")
+	format := fs.String("format", "text", "Output format: text (console log) or html (self-contained report)")
+	output := fs.String("o", "", "File to write the html report to (defaults to stdout)")
+	fs.Parse(args)
 
-	// Fetch all closed pull requests for historical analysis
-	log.Printf("Fetching closed pull requests for %s/%s...", cfg.Owner, cfg.Repo)
-	prs, err := ghClient.GetPullRequests(ctx, "closed", 100) // Fetch 100 PRs per page
+	prs, source, err := loadPrs(context.Background(), *input, *state)
 	if err != nil {
-		log.Fatalf("Error fetching pull requests: %v", err)
+		return err
+	}
+	switch *format {
+	case "text":
+		opts, err := estimateOptions()
+		if err != nil {
+			return err
+		}
+		metrics.AnalyzePrsWithOptions(prs, opts)
+	case "html":
+		return writeHTMLReport(prs, source, *output)
+	default:
+		return fmt.Errorf("unknown format %q (expected text or html)", *format)
 	}
-
-	metrics.AnalyzePrs(prs)
 
 	// You could extend this to fetch "open" PRs and try to estimate their review time
 	// based on historical data. This would involve more advanced statistical modeling.
+	return nil
+}
+
+// writeHTMLReport renders the PRs of source as an HTML report to output, or to stdout when output is empty.
+func writeHTMLReport(prs []*github.PrData, source, output string) error {
+	var allMetrics []*metrics.PrMetrics
+	for _, pr := range prs {
+		allMetrics = append(allMetrics, metrics.CalculateMetrics(pr))
+	}
+
+	title := "Pull request report: " + source
+	if output == "" {
+		return report.WriteHTML(os.Stdout, title, allMetrics)
+	}
+	f, err := os.Create(output)
+	if err != nil {
+		return err
+	}
+	if err := report.WriteHTML(f, title, allMetrics); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	log.Printf("Wrote HTML report for %d pull requests to %s", len(prs), output)
+	return nil
+}
+
+// runEstimate prints only the distribution based estimates.
+func runEstimate(args []string) error {
+	fs := flag.NewFlagSet("estimate", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	local := fs.Bool("local", false, "Estimate the commits on the current branch of a local checkout that are not on -base yet")
+	repo := fs.String("repo", ".", "Local checkout to estimate with -local")
+	base := fs.String("base", "", "Branch to diff against with -local (defaults to origin/HEAD, or main)")
+	labels := fs.String("labels", "", "Comma-separated labels the PR will get, with -local")
+	cache := fs.String("cache", "", "With -local, keep historical pull requests in this JSONL dataset between runs")
+	cacheTTL := fs.Duration("cache-ttl", 24*time.Hour, "Refetch historical pull requests when the -cache is older than this")
+	halfLife := fs.Duration("half-life", 0, "Weight PRs by recency: a PR this old counts half as much as a new one (defaults to ESTIMATE_HALF_LIFE)")
+	window := fs.Duration("window", 0, "Ignore PRs opened longer ago than this (defaults to ESTIMATE_WINDOW)")
+	outliers := fs.String("outliers", "", "Outlier handling: none, trim, winsorize or mad (defaults to ESTIMATE_OUTLIERS)")
+	percentiles := fs.String("outlier-percentiles", "", "Percentiles to trim or winsorize at, e.g. 5,95 (defaults to ESTIMATE_OUTLIER_PERCENTILES, or 5,95)")
+	exclude := fs.String("exclude", "", "Comma-separated PR numbers to leave out of estimates (adds to ESTIMATE_EXCLUDE)")
+	fs.Parse(args)
+
+	opts, err := estimateOptions()
+	if err != nil {
+		return err
+	}
+	if *halfLife > 0 {
+		opts.HalfLife = *halfLife
+	}
+	if *window > 0 {
+		opts.Window = *window
+	}
+	if *outliers != "" {
+		if opts.Outliers, err = config.ParseOutliers(*outliers); err != nil {
+			return err
+		}
+	}
+	if *percentiles != "" {
+		lower, upper, err := config.ParsePercentiles(*percentiles)
+		if err != nil {
+			return fmt.Errorf("invalid -outlier-percentiles: %w", err)
+		}
+		opts.LowerQuantile, opts.UpperQuantile = lower/100, upper/100
+	}
+	numbers, err := config.ParseNumbers(*exclude)
+	if err != nil {
+		return err
+	}
+	opts.Exclude = append(opts.Exclude, numbers...)
+	if *local {
+		return runLocalEstimate(localOptions{repo: *repo, base: *base, labels: *labels, input: *input, cache: *cache, cacheTTL: *cacheTTL, estimate: opts})
+	}
+
+	prs, _, err := loadPrs(context.Background(), *input, "closed")
+	if err != nil {
+		return err
+	}
+	var allMetrics []*metrics.PrMetrics
+	for _, pr := range prs {
+		allMetrics = append(allMetrics, metrics.CalculateMetrics(pr))
+	}
+	metrics.ReportEstimates(allMetrics, opts)
+	return nil
+}
+
+// estimateOptions weights historical PRs and handles outliers in estimates as configured by the environment.
+func estimateOptions() (metrics.EstimateOptions, error) {
+	cfg, err := config.LoadEstimateConfig()
+	if err != nil {
+		return metrics.EstimateOptions{}, fmt.Errorf("loading configuration: %w", err)
+	}
+	return metrics.EstimateOptions{
+		HalfLife:      cfg.HalfLife,
+		Window:        cfg.Window,
+		Outliers:      cfg.Outliers,
+		LowerQuantile: cfg.LowerPercentile / 100,
+		UpperQuantile: cfg.UpperPercentile / 100,
+		Exclude:       cfg.Exclude,
+	}, nil
+}
+
+// loadPrs reads pull requests from a dataset file when input is set, or fetches them otherwise.
+// It also returns the repository or project the pull requests belong to.
+func loadPrs(ctx context.Context, input, state string) ([]*github.PrData, string, error) {
+	if input != "" {
+		header, prs, err := dataset.ReadFile(input)
+		if err != nil {
+			return nil, "", fmt.Errorf("reading %s: %w", input, err)
+		}
+		log.Printf("Loaded %d pull requests for %s from %s (exported %s)", len(prs), header.Source, input, header.ExportedAt.Format(time.RFC3339))
+		return prs, header.Source, applyFeatures(ctx, nil, prs)
+	}
+
+	source, target, err := newSource()
+	if err != nil {
+		return nil, "", fmt.Errorf("loading configuration: %w", err)
+	}
+	log.Printf("Fetching %s pull requests for %s...", state, target)
+	prs, err := source.GetPullRequests(ctx, state, 100) // Fetch 100 PRs per page
+	if err != nil {
+		return nil, "", fmt.Errorf("fetching pull requests: %w", err)
+	}
+	return prs, target, applyFeatures(ctx, source, prs)
+}
+
+// applyFeatures derives features from the changed files of prs, configured by the environment.
+// Go complexity is only analyzed for PRs fetched from a source that can read files.
+func applyFeatures(ctx context.Context, source api.PullRequestSource, prs []*github.PrData) error {
+	cfg, err := config.LoadFeaturesConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	features.NewExtractor(cfg).Apply(prs)
+	if files, ok := source.(api.FileSource); ok && cfg.GoComplexity {
+		log.Printf("Analyzing Go complexity of %d pull requests...", len(prs))
+		complexity.Apply(ctx, files, prs)
+	}
+	return nil
+}
+
+// newSource builds the pull request source selected by the PR_PROVIDER environment
+// variable ("github" by default) and returns a human-readable name for what it fetches.
+func newSource() (api.PullRequestSource, string, error) {
+	switch provider := os.Getenv("PR_PROVIDER"); provider {
+	case "", "github":
+		cfg, err := config.LoadGitHubConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		return github.NewClient(cfg), cfg.Owner + "/" + cfg.Repo, nil
+	case "gitlab":
+		cfg, err := config.LoadGitLabConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		return gitlab.NewClient(cfg), cfg.Project, nil
+	case "bitbucket":
+		cfg, err := config.LoadBitbucketConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		return bitbucket.NewClient(cfg), cfg.Workspace + "/" + cfg.Repo, nil
+	case "azuredevops":
+		cfg, err := config.LoadAzureDevOpsConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		return azuredevops.NewClient(cfg), cfg.Project + "/" + cfg.Repo, nil
+	case "gerrit":
+		cfg, err := config.LoadGerritConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		return gerrit.NewClient(cfg), cfg.Project, nil
+	case "git":
+		cfg, err := config.LoadGitLocalConfig()
+		if err != nil {
+			return nil, "", err
+		}
+		return gitlocal.NewClient(cfg), cfg.Path, nil
+	default:
+		return nil, "", fmt.Errorf("unsupported PR_PROVIDER %q", provider)
+	}
 }
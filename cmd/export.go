@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/dataset"
+)
+
+// runExport fetches pull requests and writes them as a JSONL dataset for later analysis.
+func runExport(args []string) error {
+	fs := flag.NewFlagSet("export", flag.ExitOnError)
+	output := fs.String("o", "", "Output file (defaults to stdout)")
+	state := fs.String("state", "closed", "Pull request state to fetch: open, closed or all")
+	fs.Parse(args)
+
+	source, target, err := newSource()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	log.Printf("Fetching %s pull requests for %s...", *state, target)
+	ctx := context.Background()
+	prs, err := source.GetPullRequests(ctx, *state, 100)
+	if err != nil {
+		return fmt.Errorf("fetching pull requests: %w", err)
+	}
+	if err := applyFeatures(ctx, source, prs); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return dataset.Write(os.Stdout, target, prs)
+	}
+	if err := dataset.WriteFile(*output, target, prs); err != nil {
+		return err
+	}
+	log.Printf("Exported %d pull requests to %s", len(prs), *output)
+	return nil
+}
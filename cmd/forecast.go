@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runForecast simulates when a batch of open or hypothetical PRs will all be merged.
+func runForecast(args []string) error {
+	fs := flag.NewFlagSet("forecast", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset (exported with -state all) instead of the code host")
+	numbers := fs.String("prs", "", "Comma-separated open PR numbers to forecast (defaults to all open PRs unless -sizes is set)")
+	sizes := fs.String("sizes", "", "Comma-separated sizes of PRs not opened yet, as size buckets (XS, S, M, L, XL) or changed lines")
+	trials := fs.Int("trials", metrics.DefaultForecastTrials, "Number of simulations")
+	seed := fs.Uint64("seed", uint64(time.Now().UnixNano()), "Random seed, to reproduce a forecast")
+	format := fs.String("format", "text", "Output format: text or json")
+	fs.Parse(args)
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q (expected text or json)", *format)
+	}
+
+	var items []metrics.ForecastItem
+	for _, size := range strings.Split(*sizes, ",") {
+		if size = strings.TrimSpace(size); size == "" {
+			continue
+		}
+		bucket := strings.ToUpper(size)
+		if lines, err := strconv.Atoi(size); err == nil {
+			bucket = metrics.SizeBucket(lines, 0)
+		} else if !slices.Contains([]string{metrics.SizeXS, metrics.SizeS, metrics.SizeM, metrics.SizeL, metrics.SizeXL}, bucket) {
+			return fmt.Errorf("invalid size %q (expected XS, S, M, L, XL or a number of lines)", size)
+		}
+		items = append(items, metrics.ForecastItem{SizeBucket: bucket})
+	}
+	wanted := make(map[int]bool)
+	for _, n := range strings.Split(*numbers, ",") {
+		if n = strings.TrimSpace(n); n == "" {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(n, "#"))
+		if err != nil {
+			return fmt.Errorf("invalid PR number %q", n)
+		}
+		wanted[number] = true
+	}
+
+	prs, source, err := loadPrs(context.Background(), *input, "all")
+	if err != nil {
+		return err
+	}
+	var history []*metrics.PrMetrics
+	for _, pr := range prs {
+		if pr.State != "open" {
+			history = append(history, metrics.CalculateMetrics(pr))
+			continue
+		}
+		if wanted[pr.Number] || (len(wanted) == 0 && *sizes == "") {
+			delete(wanted, pr.Number)
+			items = append(items, metrics.ForecastItem{
+				Number:     pr.Number,
+				Title:      pr.Title,
+				SizeBucket: metrics.SizeBucket(pr.Additions, pr.Deletions),
+				CreatedAt:  pr.CreatedAt,
+			})
+		}
+	}
+	for number := range wanted {
+		return fmt.Errorf("PR #%d is not open in %s", number, source)
+	}
+
+	forecast, err := metrics.MonteCarloForecast(history, items, metrics.ForecastOptions{Trials: *trials, Seed: *seed, Now: time.Now()})
+	if err != nil {
+		return err
+	}
+	if *format == "json" {
+		return report.WriteForecastJSON(os.Stdout, forecast)
+	}
+	_, err = io.WriteString(os.Stdout, report.ForecastText(source, forecast))
+	return err
+}
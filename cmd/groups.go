@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runGroups prints per-author or per-label estimates, pooled toward the repository so that
+// groups with few PRs get sensible estimates with honest intervals.
+func runGroups(args []string) error {
+	fs := flag.NewFlagSet("groups", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	by := fs.String("by", "author", "Group PRs by author or label")
+	metric := fs.String("metric", "merge", "Duration to estimate: first_review or merge")
+	fs.Parse(args)
+
+	var groupBy func(*metrics.PrMetrics) []string
+	var group string
+	switch *by {
+	case "author":
+		groupBy, group = metrics.ByAuthor, "Author"
+	case "label":
+		groupBy, group = metrics.ByLabel, "Label"
+	default:
+		return fmt.Errorf("unknown grouping %q (expected author or label)", *by)
+	}
+	var selector func(*metrics.PrMetrics) time.Duration
+	var name string
+	switch *metric {
+	case "first_review":
+		selector, name = metrics.FirstReviewSelector, "Time to first review"
+	case "merge":
+		selector, name = metrics.MergeSelector, "Time to merge"
+	default:
+		return fmt.Errorf("unknown metric %q (expected first_review or merge)", *metric)
+	}
+
+	prs, source, err := loadPrs(context.Background(), *input, "closed")
+	if err != nil {
+		return err
+	}
+	var history []*metrics.PrMetrics
+	for _, pr := range prs {
+		history = append(history, metrics.CalculateMetrics(pr))
+	}
+	pooled, err := metrics.PooledGroupEstimates(history, selector, groupBy)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("%s by %s in %s\n\n", name, *by, source)
+	fmt.Print(report.PooledEstimateTable(group, pooled))
+	return nil
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/api/gitlocal"
+	"github.com/sushant-115/pr-effort-estimator/internal/dataset"
+	"github.com/sushant-115/pr-effort-estimator/internal/features"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// localOptions configure estimating the current branch of a local checkout.
+type localOptions struct {
+	repo     string
+	base     string
+	labels   string
+	input    string
+	cache    string
+	cacheTTL time.Duration
+	estimate metrics.EstimateOptions
+}
+
+// runLocalEstimate predicts review and merge times of the commits on the current branch that
+// are not on the base branch yet, from historical PRs.
+func runLocalEstimate(opts localOptions) error {
+	ctx := context.Background()
+	client := gitlocal.NewClient(&config.GitLocalConfig{Path: opts.repo, Branch: "HEAD"})
+	if opts.base == "" {
+		opts.base = client.DefaultBase(ctx)
+	}
+	local, err := client.BranchDiff(ctx, opts.base)
+	if err != nil {
+		return fmt.Errorf("diffing against %s: %w", opts.base, err)
+	}
+	for _, label := range strings.Split(opts.labels, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			local.Labels = append(local.Labels, label)
+		}
+	}
+	cfg, err := config.LoadFeaturesConfig()
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	features.NewExtractor(cfg).Apply([]*github.PrData{local})
+
+	prs, source, err := loadHistory(ctx, opts)
+	if err != nil {
+		return err
+	}
+	var history []*metrics.PrMetrics
+	for _, pr := range prs {
+		history = append(history, metrics.CalculateMetrics(pr))
+	}
+
+	pr := metrics.CalculateMetrics(local)
+	estimate := metrics.EstimateForLabels(history, pr, opts.estimate)
+	half := *pr
+	half.Additions, half.Deletions, half.ChangedFiles = pr.Additions/2, pr.Deletions/2, (pr.ChangedFiles+1)/2
+	fmt.Print(report.LocalEstimateText(source, opts.base, pr, estimate, metrics.EstimateForLabels(history, &half, opts.estimate)))
+	return nil
+}
+
+// loadHistory reads historical PRs from the -input dataset, from the cache while it is fresh and
+// of the configured repository, or fetches them and refreshes the cache.
+func loadHistory(ctx context.Context, opts localOptions) ([]*github.PrData, string, error) {
+	if opts.input != "" || opts.cache == "" {
+		return loadPrs(ctx, opts.input, "closed")
+	}
+
+	_, target, err := newSource()
+	if err != nil {
+		return nil, "", err
+	}
+	if header, prs, err := dataset.ReadFile(opts.cache); err == nil && header.Source != target {
+		log.Printf("Ignoring cache %s of %s, which isn't the configured %s", opts.cache, header.Source, target)
+	} else if err == nil && time.Since(header.ExportedAt) < opts.cacheTTL {
+		log.Printf("Using %d cached pull requests for %s from %s (exported %s)", len(prs), header.Source, opts.cache, header.ExportedAt.Format(time.RFC3339))
+		return prs, header.Source, applyFeatures(ctx, nil, prs)
+	} else if err != nil && !os.IsNotExist(err) {
+		log.Printf("Warning: Ignoring unreadable cache %s: %v", opts.cache, err)
+	}
+
+	prs, source, err := loadPrs(ctx, "", "closed")
+	if err != nil {
+		return nil, "", err
+	}
+	if err := dataset.WriteFile(opts.cache, source, prs); err != nil {
+		log.Printf("Warning: Could not write cache %s: %v", opts.cache, err)
+	}
+	return prs, source, nil
+}
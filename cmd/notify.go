@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"log"
+	"text/template"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/notify"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// notifyFlags are the flags of commands whose output can be posted to chat or webhooks.
+type notifyFlags struct {
+	enabled  *bool
+	template *string
+}
+
+func addNotifyFlags(fs *flag.FlagSet) notifyFlags {
+	return notifyFlags{
+		enabled:  fs.Bool("notify", false, "Also post the output to the webhooks configured by NOTIFY_SLACK_WEBHOOK_URL, NOTIFY_TEAMS_WEBHOOK_URL and NOTIFY_WEBHOOK_URL"),
+		template: fs.String("template", "", "text/template file for the posted message ({{.Title}}, {{.Text}} and command-specific {{.Data}})"),
+	}
+}
+
+// send posts the message to every configured webhook when -notify is set.
+func (f notifyFlags) send(ctx context.Context, title, text string, data any) error {
+	if !*f.enabled {
+		return nil
+	}
+	cfg, err := config.LoadNotifyConfig()
+	if err != nil {
+		return err
+	}
+	var tmpl *template.Template
+	if *f.template != "" {
+		if tmpl, err = notify.LoadTemplate(*f.template); err != nil {
+			return err
+		}
+	}
+	msg, err := notify.Render(tmpl, title, text, data)
+	if err != nil {
+		return err
+	}
+
+	var notifiers []notify.Notifier
+	if cfg.SlackWebhookURL != "" {
+		notifiers = append(notifiers, notify.NewSlack(cfg.SlackWebhookURL))
+	}
+	if cfg.TeamsWebhookURL != "" {
+		notifiers = append(notifiers, notify.NewTeams(cfg.TeamsWebhookURL))
+	}
+	if cfg.WebhookURL != "" {
+		notifiers = append(notifiers, notify.NewJSON(cfg.WebhookURL))
+	}
+	if err := notify.NotifyAll(ctx, notifiers, msg); err != nil {
+		return err
+	}
+	log.Printf("Posted %q to %d webhooks", title, len(notifiers))
+	return nil
+}
@@ -0,0 +1,84 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/sushant-115/pr-effort-estimator/api"
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/owners"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runOwners prints review latency per CODEOWNERS owner and per top-level directory.
+func runOwners(args []string) error {
+	fs := flag.NewFlagSet("owners", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	state := fs.String("state", "all", "Pull request state to fetch: open, closed or all")
+	codeowners := fs.String("codeowners", "", "Read CODEOWNERS from this file instead of the repository")
+	fs.Parse(args)
+
+	ctx := context.Background()
+	prs, source, err := loadPrs(ctx, *input, *state)
+	if err != nil {
+		return err
+	}
+	co, err := loadCodeOwners(ctx, *codeowners, *input != "")
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Review latency by area in %s (%d pull requests)\n\n", source, len(prs))
+	if co != nil {
+		fmt.Println("By owner (CODEOWNERS):")
+		fmt.Print(report.GroupLatencyTable("Owner", metrics.LatencyByGroup(prs, func(pr *github.PrData) []string {
+			paths := make([]string, 0, len(pr.Files))
+			for _, f := range pr.Files {
+				paths = append(paths, f.Path)
+			}
+			return co.PathOwners(paths)
+		})))
+		fmt.Println()
+	}
+	fmt.Println("By top-level directory:")
+	fmt.Print(report.GroupLatencyTable("Directory", metrics.LatencyByGroup(prs, metrics.TopLevelDirectories)))
+	return nil
+}
+
+// loadCodeOwners reads CODEOWNERS from path, or from the repository when path is empty. It
+// returns nil when no CODEOWNERS file is available, and doesn't contact the code host when the
+// pull requests were read from a dataset, so that offline analysis keeps working.
+func loadCodeOwners(ctx context.Context, path string, offline bool) (*owners.CodeOwners, error) {
+	if path != "" {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return owners.Parse(f)
+	}
+
+	if offline {
+		log.Printf("Warning: Pull requests were read from a dataset; pass -codeowners to report latency per owner")
+		return nil, nil
+	}
+	source, _, err := newSource()
+	if err != nil {
+		log.Printf("Warning: Cannot read CODEOWNERS from the code host (%v); reporting latency per directory only", err)
+		return nil, nil
+	}
+	files, ok := source.(api.FileSource)
+	if !ok {
+		log.Printf("Warning: This provider cannot read CODEOWNERS; pass -codeowners to report latency per owner")
+		return nil, nil
+	}
+	co, err := owners.Fetch(ctx, files)
+	if co == nil && err == nil {
+		log.Printf("Warning: No CODEOWNERS file found in %v; reporting latency per directory only", owners.Locations)
+	}
+	return co, err
+}
@@ -0,0 +1,49 @@
+package cmd_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/cmd"
+	"github.com/sushant-115/pr-effort-estimator/internal/dataset"
+)
+
+func TestOwners_InputWithoutCredentials(t *testing.T) {
+	created := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	merged := created.Add(20 * time.Hour)
+	input := filepath.Join(t.TempDir(), "prs.jsonl")
+	prs := []*github.PrData{{
+		Number: 1, State: "merged", CreatedAt: created, MergedAt: &merged,
+		Files: []github.FileChange{{Path: "api/client.go", Additions: 10}},
+	}}
+	if err := dataset.WriteFile(input, "octo/repo", prs); err != nil {
+		t.Fatal(err)
+	}
+
+	// Without a provider configuration, reading CODEOWNERS from the code host must not be fatal
+	t.Setenv("PR_PROVIDER", "")
+	t.Setenv("GITHUB_TOKEN", "")
+	args := os.Args
+	os.Args = []string{"pr-effort-estimator", "owners", "-input", input}
+	defer func() { os.Args = args }()
+
+	stdout := os.Stdout
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Stdout = w
+	cmd.Run()
+	w.Close()
+	os.Stdout = stdout
+	out, _ := io.ReadAll(r)
+
+	if !strings.Contains(string(out), "By top-level directory:") || strings.Contains(string(out), "By owner") {
+		t.Errorf("Expected latency per directory only, got:\n%s", out)
+	}
+}
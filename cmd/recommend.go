@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runRecommend ranks reviewers by how soon they are expected to review a PR.
+func runRecommend(args []string) error {
+	fs := flag.NewFlagSet("recommend", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset (exported with -state all) instead of the code host")
+	number := fs.Int("pr", 0, "Recommend reviewers for this pull request, using its author and files")
+	author := fs.String("author", "", "Author of the change, never recommended (when -pr is not set)")
+	files := fs.String("files", "", "Comma-separated paths changed (when -pr is not set)")
+	top := fs.Int("top", 5, "Number of reviewers to list (0 lists all)")
+	fs.Parse(args)
+
+	prs, source, err := loadPrs(context.Background(), *input, "all")
+	if err != nil {
+		return err
+	}
+
+	var paths []string
+	for _, f := range strings.Split(*files, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			paths = append(paths, f)
+		}
+	}
+	history := prs
+	title := "the change"
+	if *number > 0 {
+		var pr *github.PrData
+		history = nil
+		for _, p := range prs {
+			if p.Number == *number {
+				pr = p
+			} else {
+				history = append(history, p)
+			}
+		}
+		if pr == nil {
+			return fmt.Errorf("PR #%d not found in %s", *number, source)
+		}
+		*author = pr.Author
+		paths = nil
+		for _, f := range pr.Files {
+			paths = append(paths, f.Path)
+		}
+		title = fmt.Sprintf("PR #%d (%s)", pr.Number, pr.Title)
+	}
+
+	recommendations := metrics.RecommendReviewers(history, *author, paths, metrics.DefaultRecommendOptions, time.Now())
+	if *top > 0 && len(recommendations) > *top {
+		recommendations = recommendations[:*top]
+	}
+	fmt.Printf("Recommended reviewers for %s in %s (%d changed files)\n\n", title, source, len(paths))
+	fmt.Print(report.RecommendationTable(recommendations))
+	return nil
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runReviewers prints review counts, responsiveness and open requests per reviewer.
+func runReviewers(args []string) error {
+	fs := flag.NewFlagSet("reviewers", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	state := fs.String("state", "all", "Pull request state to fetch: open, closed or all")
+	fs.Parse(args)
+
+	prs, source, err := loadPrs(context.Background(), *input, *state)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Review workload in %s (%d pull requests)\n\n", source, len(prs))
+	fmt.Print(report.ReviewerWorkloadTable(metrics.AnalyzeReviewerWorkload(prs, time.Now())))
+	return nil
+}
@@ -0,0 +1,140 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/exporter"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/server"
+	"github.com/sushant-115/pr-effort-estimator/internal/webhook"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// runServe serves metrics and estimates over HTTP, refreshing PR data in the background.
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := fs.String("addr", ":8080", "Address to listen on")
+	refresh := fs.Duration("refresh", 15*time.Minute, "Interval between background refreshes")
+	repos := fs.String("repos", "", "Comma-separated owner/repo list to serve (GitHub only; defaults to the configured repository)")
+	fs.Parse(args)
+
+	served, fetch, ghCfg, err := newFetcher(*repos)
+	if err != nil {
+		return fmt.Errorf("loading configuration: %w", err)
+	}
+	opts, err := estimateOptions()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	store := server.NewStore(served, fetch)
+	log.Printf("Fetching initial data for %s...", strings.Join(served, ", "))
+	if err := store.Refresh(ctx); err != nil {
+		log.Printf("Warning: %v", err)
+	}
+	go store.Run(ctx, *refresh)
+
+	srv := server.New(store, *refresh, opts)
+	srv.Handle("GET /metrics", exporter.New(store))
+	if ghCfg != nil && ghCfg.WebhookSecret != "" {
+		srv.Handle("POST /webhook", webhook.NewHandler(ghCfg.WebhookSecret,
+			func(repo string) ([]*metrics.PrMetrics, bool) {
+				snap, ok := store.Snapshot(repo)
+				if !ok {
+					return nil, false
+				}
+				return snap.Metrics, true
+			},
+			func(repo string) webhook.Commenter {
+				repoCfg := *ghCfg
+				repoCfg.Owner, repoCfg.Repo, _ = strings.Cut(repo, "/")
+				return github.NewClient(&repoCfg)
+			},
+			opts,
+		))
+		log.Println("Accepting GitHub webhooks on /webhook")
+	}
+
+	log.Printf("Listening on %s", *addr)
+	return http.ListenAndServe(*addr, srv)
+}
+
+// newFetcher returns the repositories to serve and how to fetch them, plus the GitHub
+// configuration when serving GitHub. GitHub can serve any number of repositories with
+// one token; other providers serve their single configured target under servedName.
+func newFetcher(repos string) ([]string, server.Fetcher, *config.GitHubConfig, error) {
+	if provider := os.Getenv("PR_PROVIDER"); provider != "" && provider != "github" {
+		if repos != "" {
+			return nil, nil, nil, fmt.Errorf("-repos is only supported for GitHub")
+		}
+		source, target, err := newSource()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		name := servedName(provider, target)
+		if name != target {
+			log.Printf("Serving %s as /repos/%s", target, name)
+		}
+		return []string{name}, func(ctx context.Context, repo string) ([]*github.PrData, error) {
+			prs, err := source.GetPullRequests(ctx, "all", 100)
+			if err != nil {
+				return nil, err
+			}
+			return prs, applyFeatures(ctx, source, prs)
+		}, nil, nil
+	}
+
+	cfg, err := config.LoadGitHubConfig()
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	served := []string{cfg.Owner + "/" + cfg.Repo}
+	if repos != "" {
+		served = strings.Split(repos, ",")
+	}
+	for i, repo := range served {
+		repo = strings.TrimSpace(repo)
+		served[i] = repo
+		if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" {
+			return nil, nil, nil, fmt.Errorf("invalid repository %q, expected owner/repo", repo)
+		}
+	}
+
+	return served, func(ctx context.Context, repo string) ([]*github.PrData, error) {
+		repoCfg := *cfg
+		repoCfg.Owner, repoCfg.Repo, _ = strings.Cut(repo, "/")
+		client := github.NewClient(&repoCfg)
+		prs, err := client.GetPullRequests(ctx, "all", 100)
+		if err != nil {
+			return nil, err
+		}
+		return prs, applyFeatures(ctx, client, prs)
+	}, cfg, nil
+}
+
+// servedName maps a target to the owner/repo form the routes match. Local paths are served as
+// git/ and the name of the repository's directory, e.g. "git/myrepo" for GIT_REPO_PATH=/src/myrepo
+// or for GIT_REPO_PATH=. inside it. Other targets that don't have that form, like GitLab
+// subgroups and Gerrit projects, are served as provider/name.
+func servedName(provider, target string) string {
+	if provider == "git" {
+		if abs, err := filepath.Abs(target); err == nil {
+			target = abs
+		}
+		return provider + "/" + filepath.Base(target)
+	}
+	if owner, repo, ok := strings.Cut(target, "/"); ok && owner != "" && repo != "" && !strings.Contains(repo, "/") {
+		return target
+	}
+	return provider + "/" + filepath.Base(filepath.Clean(target))
+}
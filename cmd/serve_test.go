@@ -0,0 +1,82 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/cmd"
+)
+
+func TestServe_GitLocal(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := filepath.Join(t.TempDir(), "myrepo")
+	git := func(args ...string) {
+		c := exec.Command("git", append([]string{"-C", dir}, args...)...)
+		c.Env = append(os.Environ(), "GIT_AUTHOR_NAME=alice", "GIT_AUTHOR_EMAIL=alice@example.com",
+			"GIT_COMMITTER_NAME=alice", "GIT_COMMITTER_EMAIL=alice@example.com")
+		if out, err := c.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	git("init", "-q", "-b", "main")
+	for _, msg := range []string{"Add README (#1)", "Update README (#2)"} {
+		if err := os.WriteFile(filepath.Join(dir, "README.md"), []byte(msg), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	// A relative path is served under the name of the directory it resolves to
+	t.Chdir(dir)
+	t.Setenv("PR_PROVIDER", "git")
+	t.Setenv("GIT_REPO_PATH", ".")
+	t.Setenv("GIT_BRANCH", "main")
+	args := os.Args
+	os.Args = []string{"pr-effort-estimator", "serve", "-addr", addr}
+	defer func() { os.Args = args }()
+	go cmd.Run()
+
+	// The local path can't be requested as /repos/{owner}/{repo}, so it is served as git/myrepo
+	var resp *http.Response
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(50 * time.Millisecond) {
+		if resp, err = http.Get("http://" + addr + "/repos/git/myrepo/metrics"); err == nil && resp.StatusCode == http.StatusOK {
+			break
+		}
+	}
+	if err != nil {
+		t.Fatalf("serve did not answer: %v", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 OK, got %s", resp.Status)
+	}
+	var body struct {
+		Repo    string `json:"repo"`
+		PrCount int    `json:"pr_count"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	if body.Repo != "git/myrepo" || body.PrCount != 2 {
+		t.Errorf("Expected 2 PRs served as git/myrepo, got %+v", body)
+	}
+}
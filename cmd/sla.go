@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+	"github.com/sushant-115/pr-effort-estimator/internal/sla"
+)
+
+// runSLA reports compliance with review SLAs, breaching PRs and open PRs at risk of breaching.
+func runSLA(args []string) error {
+	fs := flag.NewFlagSet("sla", flag.ExitOnError)
+	rulesPath := fs.String("rules", "sla.json", "JSON file with the SLA rules")
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	state := fs.String("state", "all", "Pull request state to fetch: open, closed or all")
+	period := fs.String("period", metrics.PeriodWeek, "Period for compliance over time: week or month")
+	failOnBreach := fs.Bool("fail-on-breach", false, "Exit with an error when an open PR breaches an SLA, for CI gating")
+	notifications := addNotifyFlags(fs)
+	fs.Parse(args)
+
+	if *period != metrics.PeriodWeek && *period != metrics.PeriodMonth {
+		return fmt.Errorf("unknown period %q (expected %s or %s)", *period, metrics.PeriodWeek, metrics.PeriodMonth)
+	}
+	cfg, err := sla.Load(*rulesPath)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+	prs, source, err := loadPrs(ctx, *input, *state)
+	if err != nil {
+		return err
+	}
+	var allMetrics []*metrics.PrMetrics
+	for _, pr := range prs {
+		allMetrics = append(allMetrics, metrics.CalculateMetrics(pr))
+	}
+
+	now := time.Now()
+	reports := cfg.Evaluate(source, allMetrics, *period, now)
+	summary := report.SLASummary(reports, *period, now)
+	fmt.Print(summary)
+
+	// Only open PRs gate, so that breaches in the history don't fail every later run
+	breaches := 0
+	for _, r := range reports {
+		breaches += len(r.OpenBreaches())
+	}
+	if err := notifications.send(ctx, "Review SLAs for "+source, summary, reports); err != nil {
+		return err
+	}
+
+	if *failOnBreach && breaches > 0 {
+		return fmt.Errorf("%d SLA breaches by open PRs", breaches)
+	}
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runStale prints a digest of open PRs that have been open or idle for too long.
+func runStale(args []string) error {
+	fs := flag.NewFlagSet("stale", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset (exported with -state all) instead of the code host")
+	maxAge := fs.Duration("max-age", 14*24*time.Hour, "Open PRs older than this are stale (0 disables)")
+	maxIdle := fs.Duration("max-idle", 3*24*time.Hour, "Open PRs without pushes, reviews or other activity for this long are stale (0 disables)")
+	notifications := addNotifyFlags(fs)
+	fs.Parse(args)
+
+	ctx := context.Background()
+	prs, source, err := loadPrs(ctx, *input, "all")
+	if err != nil {
+		return err
+	}
+	var history []*metrics.PrMetrics
+	for _, pr := range prs {
+		if pr.State != "open" {
+			history = append(history, metrics.CalculateMetrics(pr))
+		}
+	}
+
+	stale := metrics.FindStale(prs, history, metrics.StaleOptions{MaxAge: *maxAge, MaxIdle: *maxIdle}, time.Now())
+	digest := report.StaleDigest(source, stale)
+	fmt.Print(digest)
+	if len(stale) == 0 {
+		return nil
+	}
+	return notifications.send(ctx, fmt.Sprintf("Stale pull requests in %s", source), digest, stale)
+}
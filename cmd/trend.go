@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// runTrend prints rolling review latency percentiles and throughput per week or month.
+func runTrend(args []string) error {
+	fs := flag.NewFlagSet("trend", flag.ExitOnError)
+	input := fs.String("input", "", "Read pull requests from a JSONL dataset instead of the code host")
+	period := fs.String("period", metrics.PeriodWeek, "Period length: week or month")
+	window := fs.Int("window", 4, "Number of periods pooled into each rolling percentile (1 disables smoothing)")
+	format := fs.String("format", "text", "Output format: text (sparklines), json or csv")
+	output := fs.String("o", "", "Output file (defaults to stdout)")
+	notifications := addNotifyFlags(fs)
+	fs.Parse(args)
+
+	var write func(io.Writer, []metrics.TrendPoint) error
+	switch *format {
+	case "text":
+		write = func(w io.Writer, points []metrics.TrendPoint) error {
+			_, err := io.WriteString(w, report.TrendSparklines(points))
+			return err
+		}
+	case "json":
+		write = report.WriteTrendJSON
+	case "csv":
+		write = report.WriteTrendCSV
+	default:
+		return fmt.Errorf("unknown format %q (expected text, json or csv)", *format)
+	}
+
+	ctx := context.Background()
+	prs, source, err := loadPrs(ctx, *input, "closed")
+	if err != nil {
+		return err
+	}
+	var allMetrics []*metrics.PrMetrics
+	for _, pr := range prs {
+		allMetrics = append(allMetrics, metrics.CalculateMetrics(pr))
+	}
+	points, err := metrics.Trend(allMetrics, metrics.TrendOptions{Period: *period, Window: *window})
+	if err != nil {
+		return err
+	}
+
+	// The summary posted by -notify is always the sparklines, whatever the output format
+	if err := notifications.send(ctx, "Review latency trend for "+source, report.TrendSparklines(points), points); err != nil {
+		return err
+	}
+
+	if *output == "" {
+		return write(os.Stdout, points)
+	}
+	f, err := os.Create(*output)
+	if err != nil {
+		return err
+	}
+	if err := write(f, points); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
@@ -0,0 +1,76 @@
+// Package actions implements the GitHub Actions workflow commands used to report
+// estimates: job summaries, step outputs and ETA labels.
+package actions
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AppendSummary appends Markdown to the job summary file (GITHUB_STEP_SUMMARY).
+func AppendSummary(path, markdown string) error {
+	return appendFile(path, markdown+"\n")
+}
+
+// SetOutputs writes step outputs to the GITHUB_OUTPUT file, using the heredoc syntax
+// so values may span multiple lines. Keys are written in sorted order.
+func SetOutputs(path string, outputs map[string]string) error {
+	keys := make([]string, 0, len(outputs))
+	for key := range outputs {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var b strings.Builder
+	for _, key := range keys {
+		delimiter, err := newDelimiter()
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(&b, "%s<<%s\n%s\n%s\n", key, delimiter, outputs[key], delimiter)
+	}
+	return appendFile(path, b.String())
+}
+
+// ETALabel formats a duration as a coarse label such as "review-eta:4h", "review-eta:2d" or "review-eta:3w",
+// rounding up so the label never promises more than the estimate.
+func ETALabel(prefix string, d time.Duration) string {
+	hours := math.Ceil(d.Hours())
+	switch {
+	case hours < 1:
+		return prefix + "1h"
+	case hours < 24:
+		return fmt.Sprintf("%s%dh", prefix, int(hours))
+	case hours < 14*24:
+		return fmt.Sprintf("%s%dd", prefix, int(math.Ceil(hours/24)))
+	default:
+		return fmt.Sprintf("%s%dw", prefix, int(math.Ceil(hours/(7*24))))
+	}
+}
+
+func appendFile(path, content string) error {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// newDelimiter returns a random heredoc delimiter that can't collide with output values.
+func newDelimiter() (string, error) {
+	buf := make([]byte, 8)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "ghadelimiter_" + hex.EncodeToString(buf), nil
+}
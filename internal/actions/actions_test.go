@@ -0,0 +1,61 @@
+package actions_test
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/actions"
+)
+
+func TestSetOutputs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "output")
+	err := actions.SetOutputs(path, map[string]string{
+		"size-bucket": "M",
+		"estimate":    "line one\nline two",
+	})
+	if err != nil {
+		t.Fatalf("SetOutputs failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	pattern := regexp.MustCompile(`(?s)^estimate<<(ghadelimiter_\w+)\nline one\nline two\n(ghadelimiter_\w+)\nsize-bucket<<(ghadelimiter_\w+)\nM\n(ghadelimiter_\w+)\n$`)
+	m := pattern.FindStringSubmatch(string(content))
+	if m == nil {
+		t.Fatalf("Unexpected output file:\n%s", content)
+	}
+	if m[1] != m[2] || m[3] != m[4] {
+		t.Errorf("Expected matching heredoc delimiters, got:\n%s", content)
+	}
+}
+
+func TestAppendSummary(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "summary.md")
+	os.WriteFile(path, []byte("# Earlier step\n"), 0o644)
+
+	if err := actions.AppendSummary(path, "### Estimate"); err != nil {
+		t.Fatalf("AppendSummary failed: %v", err)
+	}
+	content, _ := os.ReadFile(path)
+	if !strings.HasPrefix(string(content), "# Earlier step\n") || !strings.HasSuffix(string(content), "### Estimate\n") {
+		t.Errorf("Expected the summary to be appended, got:\n%s", content)
+	}
+}
+
+func TestETALabel(t *testing.T) {
+	cases := map[time.Duration]string{
+		20 * time.Minute:             "review-eta:1h",
+		3*time.Hour + 10*time.Minute: "review-eta:4h",
+		23 * time.Hour:               "review-eta:23h",
+		30 * time.Hour:               "review-eta:2d",
+		20 * 24 * time.Hour:          "review-eta:3w",
+	}
+	for d, expected := range cases {
+		if got := actions.ETALabel("review-eta:", d); got != expected {
+			t.Errorf("ETALabel(%v) = %q, expected %q", d, got, expected)
+		}
+	}
+}
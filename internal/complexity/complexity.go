@@ -0,0 +1,324 @@
+// Package complexity measures how pull requests change Go code, by parsing the base and head
+// versions of their .go files with go/ast.
+package complexity
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"go/types"
+	"log"
+	"path"
+	"strings"
+
+	"github.com/sushant-115/pr-effort-estimator/api"
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// Delta is how one Go file changed between two versions.
+type Delta struct {
+	CyclomaticDelta    int
+	ChangedFunctions   int
+	ExportedAPIChanges int
+	Generated          bool // Either version is marked as generated; generated files are not compared
+}
+
+// file is what Compare needs to know about one version of a Go file.
+type file struct {
+	funcs     map[string]function // Keyed by name, or "(Recv).Name" for methods
+	api       map[string]string   // Exported declarations and their signatures
+	generated bool
+}
+
+type function struct {
+	complexity int
+	source     string // Printed without comments, so formatting and comment changes don't count
+}
+
+// Compare measures how a Go file changed from base to head. A nil version means the file
+// doesn't exist on that side, i.e. it was added or removed.
+func Compare(base, head []byte) (Delta, error) {
+	b, err := parse(base)
+	if err != nil {
+		return Delta{}, fmt.Errorf("parsing base version: %w", err)
+	}
+	h, err := parse(head)
+	if err != nil {
+		return Delta{}, fmt.Errorf("parsing head version: %w", err)
+	}
+	if b.generated || h.generated {
+		return Delta{Generated: true}, nil
+	}
+
+	var d Delta
+	for name, f := range h.funcs {
+		d.CyclomaticDelta += f.complexity
+		if old, ok := b.funcs[name]; !ok || old.source != f.source {
+			d.ChangedFunctions++
+		}
+	}
+	for name, f := range b.funcs {
+		d.CyclomaticDelta -= f.complexity
+		if _, ok := h.funcs[name]; !ok {
+			d.ChangedFunctions++
+		}
+	}
+	for name, signature := range h.api {
+		if old, ok := b.api[name]; !ok || old != signature {
+			d.ExportedAPIChanges++
+		}
+	}
+	for name := range b.api {
+		if _, ok := h.api[name]; !ok {
+			d.ExportedAPIChanges++
+		}
+	}
+	return d, nil
+}
+
+// Analyze compares the base and head versions of the Go files a PR changed, skipping vendored
+// and generated code, and files that can't be fetched with a warning. A directory whose changed
+// Go files are all new counts as a new package unless it had Go files at the base commit.
+func Analyze(ctx context.Context, source api.FileSource, pr *github.PrData) (*github.GoComplexity, error) {
+	if pr.BaseSHA == "" || pr.HeadSHA == "" {
+		return nil, fmt.Errorf("base and head commits of PR #%d are unknown", pr.Number)
+	}
+
+	result := &github.GoComplexity{}
+	newDirs := make(map[string]bool)
+	for _, f := range pr.Files {
+		if path.Ext(f.Path) != ".go" || strings.HasPrefix(f.Path, "vendor/") || strings.Contains(f.Path, "/vendor/") {
+			continue
+		}
+		basePath := f.Path
+		if f.PreviousPath != "" {
+			basePath = f.PreviousPath
+		}
+		base, err := version(ctx, source, basePath, pr.BaseSHA, f.Status, "added")
+		if err != nil {
+			log.Printf("Warning: Skipping %s in the complexity analysis of PR #%d: %v", f.Path, pr.Number, err)
+			continue
+		}
+		head, err := version(ctx, source, f.Path, pr.HeadSHA, f.Status, "removed")
+		if err != nil {
+			log.Printf("Warning: Skipping %s in the complexity analysis of PR #%d: %v", f.Path, pr.Number, err)
+			continue
+		}
+		d, err := Compare(base, head)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f.Path, err)
+		}
+		if d.Generated {
+			continue
+		}
+
+		result.Files++
+		result.CyclomaticDelta += d.CyclomaticDelta
+		result.ChangedFunctions += d.ChangedFunctions
+		result.ExportedAPIChanges += d.ExportedAPIChanges
+		dir := path.Dir(f.Path)
+		if isNew, seen := newDirs[dir]; !seen || isNew {
+			newDirs[dir] = base == nil && head != nil
+		}
+	}
+	for dir, isNew := range newDirs {
+		if isNew && !hasGoFiles(ctx, source, dir, pr.BaseSHA) {
+			result.NewPackages++
+		}
+	}
+	return result, nil
+}
+
+// hasGoFiles reports whether dir held Go files at ref. Without a DirectorySource, or when the
+// directory can't be listed, it assumes so rather than report a new package that may not be one.
+func hasGoFiles(ctx context.Context, source api.FileSource, dir, ref string) bool {
+	lister, ok := source.(api.DirectorySource)
+	if !ok {
+		return true
+	}
+	names, err := lister.ListDirectory(ctx, dir, ref)
+	if errors.Is(err, github.ErrFileNotFound) {
+		return false
+	}
+	if err != nil {
+		log.Printf("Warning: Could not list %s at %s: %v", dir, ref, err)
+		return true
+	}
+	for _, name := range names {
+		if path.Ext(name) == ".go" {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply sets the Go complexity features of PRs that changed Go files and don't have them yet,
+// logging PRs that can't be analyzed.
+func Apply(ctx context.Context, source api.FileSource, prs []*github.PrData) {
+	for _, pr := range prs {
+		if pr.Features == nil || pr.Features.GoComplexity != nil || !changesGo(pr) {
+			continue
+		}
+		c, err := Analyze(ctx, source, pr)
+		if err != nil {
+			log.Printf("Warning: Could not analyze Go complexity of PR #%d: %v", pr.Number, err)
+			continue
+		}
+		pr.Features.GoComplexity = c
+	}
+}
+
+func changesGo(pr *github.PrData) bool {
+	for _, f := range pr.Files {
+		if path.Ext(f.Path) == ".go" {
+			return true
+		}
+	}
+	return false
+}
+
+// version fetches a file at ref, or returns nil when its status says it doesn't exist there.
+// Without a status, a file that can't be fetched is taken not to exist.
+func version(ctx context.Context, source api.FileSource, path, ref, status, missingStatus string) ([]byte, error) {
+	if status == missingStatus {
+		return nil, nil
+	}
+	content, err := source.GetFile(ctx, path, ref)
+	if err != nil {
+		if status == "" {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("fetching %s at %s: %w", path, ref, err)
+	}
+	return content, nil
+}
+
+// parse summarizes the functions and exported API of a Go source file; nil src is an empty file.
+func parse(src []byte) (file, error) {
+	f := file{funcs: make(map[string]function), api: make(map[string]string)}
+	if src == nil {
+		return f, nil
+	}
+	fset := token.NewFileSet()
+	parsed, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return f, err
+	}
+	f.generated = ast.IsGenerated(parsed)
+
+	for _, decl := range parsed.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			name := decl.Name.Name
+			receiver := ""
+			if decl.Recv != nil && len(decl.Recv.List) > 0 {
+				receiver = types.ExprString(decl.Recv.List[0].Type)
+				name = "(" + receiver + ")." + name
+			}
+			undocumented := *decl
+			undocumented.Doc = nil
+			var source bytes.Buffer
+			printer.Fprint(&source, fset, &undocumented)
+			f.funcs[name] = function{complexity: Cyclomatic(decl), source: source.String()}
+
+			if decl.Name.IsExported() && (receiver == "" || ast.IsExported(receiverType(receiver))) {
+				f.api["func "+name] = types.ExprString(decl.Type)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if spec.Name.IsExported() {
+						f.api["type "+spec.Name.Name] = typeSignature(spec)
+					}
+				case *ast.ValueSpec:
+					for _, n := range spec.Names {
+						if n.IsExported() {
+							signature := decl.Tok.String()
+							if spec.Type != nil {
+								signature += " " + types.ExprString(spec.Type)
+							}
+							f.api["value "+n.Name] = signature
+						}
+					}
+				}
+			}
+		}
+	}
+	return f, nil
+}
+
+// Cyclomatic returns the cyclomatic complexity of a function: one plus the number of branches
+// (if, for, non-default case and select clauses, && and ||), including nested function literals.
+func Cyclomatic(fn *ast.FuncDecl) int {
+	complexity := 1
+	if fn.Body == nil {
+		return complexity
+	}
+	ast.Inspect(fn.Body, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.IfStmt, *ast.ForStmt, *ast.RangeStmt:
+			complexity++
+		case *ast.CaseClause:
+			if n.List != nil {
+				complexity++
+			}
+		case *ast.CommClause:
+			if n.Comm != nil {
+				complexity++
+			}
+		case *ast.BinaryExpr:
+			if n.Op == token.LAND || n.Op == token.LOR {
+				complexity++
+			}
+		}
+		return true
+	})
+	return complexity
+}
+
+// typeSignature describes the exported surface of a type: its type parameters and definition,
+// leaving out unexported struct fields.
+func typeSignature(spec *ast.TypeSpec) string {
+	var b strings.Builder
+	if spec.TypeParams != nil {
+		for _, p := range spec.TypeParams.List {
+			fmt.Fprintf(&b, "[%d %s]", len(p.Names), types.ExprString(p.Type))
+		}
+	}
+	if spec.Assign.IsValid() {
+		b.WriteString("= ")
+	}
+	st, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		b.WriteString(types.ExprString(spec.Type))
+		return b.String()
+	}
+	b.WriteString("struct{")
+	for _, field := range st.Fields.List {
+		if len(field.Names) == 0 {
+			// Embedded fields are part of the API through their promoted fields and methods
+			fmt.Fprintf(&b, "%s;", types.ExprString(field.Type))
+			continue
+		}
+		for _, n := range field.Names {
+			if n.IsExported() {
+				fmt.Fprintf(&b, "%s %s;", n.Name, types.ExprString(field.Type))
+			}
+		}
+	}
+	b.WriteString("}")
+	return b.String()
+}
+
+// receiverType strips pointers and type arguments from a printed receiver, e.g. "*List[T]" to "List".
+func receiverType(receiver string) string {
+	receiver = strings.TrimPrefix(receiver, "*")
+	name, _, _ := strings.Cut(receiver, "[")
+	return name
+}
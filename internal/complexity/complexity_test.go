@@ -0,0 +1,177 @@
+package complexity_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/complexity"
+)
+
+const base = `package cache
+
+// Cache stores values.
+type Cache struct {
+	Size  int
+	items map[string]string
+}
+
+func (c *Cache) Get(key string) string {
+	return c.items[key]
+}
+
+func helper(a, b bool) bool {
+	if a && b {
+		return true
+	}
+	return false
+}
+
+func Removed() {}
+`
+
+const head = `package cache
+
+// Cache stores values, evicting the oldest.
+type Cache struct {
+	Size  int
+	items map[string]string
+	order []string
+}
+
+// Get returns the value of key.
+func (c *Cache) Get(key string) (string, bool) {
+	v, ok := c.items[key]
+	return v, ok
+}
+
+func helper(a, b bool) bool {
+	if a && b { return true }
+	return false
+}
+
+func (c *Cache) evict() {
+	for len(c.order) > c.Size {
+		switch {
+		case c.Size == 0:
+			return
+		default:
+			delete(c.items, c.order[0])
+			c.order = c.order[1:]
+		}
+	}
+}
+`
+
+func TestCyclomatic(t *testing.T) {
+	f, err := parser.ParseFile(token.NewFileSet(), "", head, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := map[string]int{"Get": 1, "helper": 3, "evict": 3}
+	for _, decl := range f.Decls {
+		if fn, ok := decl.(*ast.FuncDecl); ok {
+			if got := complexity.Cyclomatic(fn); got != want[fn.Name.Name] {
+				t.Errorf("Cyclomatic(%s) = %d, want %d", fn.Name.Name, got, want[fn.Name.Name])
+			}
+		}
+	}
+}
+
+func TestCompare(t *testing.T) {
+	d, err := complexity.Compare([]byte(base), []byte(head))
+	if err != nil {
+		t.Fatalf("Compare failed: %v", err)
+	}
+	// evict adds 3, Removed takes 1 away; reformatting helper and the unexported field change nothing
+	if d.CyclomaticDelta != 2 {
+		t.Errorf("Expected cyclomatic delta 2, got %d", d.CyclomaticDelta)
+	}
+	if d.ChangedFunctions != 3 {
+		t.Errorf("Expected Get, evict and Removed to change, got %d", d.ChangedFunctions)
+	}
+	if d.ExportedAPIChanges != 2 {
+		t.Errorf("Expected Get's signature and Removed to change the API, got %d", d.ExportedAPIChanges)
+	}
+
+	added, err := complexity.Compare(nil, []byte(base))
+	if err != nil || added.ChangedFunctions != 3 || added.ExportedAPIChanges != 3 || added.CyclomaticDelta != 5 {
+		t.Errorf("Unexpected delta for a new file: %+v (err %v)", added, err)
+	}
+
+	generated, _ := complexity.Compare(nil, []byte("// Code generated by protoc-gen-go. DO NOT EDIT.\n\npackage pb\n\nfunc A() {}\n"))
+	if !generated.Generated || generated.ChangedFunctions != 0 {
+		t.Errorf("Expected generated code to be skipped, got %+v", generated)
+	}
+
+	if _, err := complexity.Compare(nil, []byte("package broken\nfunc {")); err == nil {
+		t.Error("Expected an error for unparsable code")
+	}
+}
+
+// files is a FileSource serving "ref:path" keys.
+type files map[string]string
+
+func (f files) GetFile(_ context.Context, path, ref string) ([]byte, error) {
+	content, ok := f[ref+":"+path]
+	if !ok {
+		return nil, errors.New("not found")
+	}
+	return []byte(content), nil
+}
+
+func (f files) ListDirectory(_ context.Context, dir, ref string) ([]string, error) {
+	var names []string
+	for key := range f {
+		if name, ok := strings.CutPrefix(key, ref+":"+dir+"/"); ok && !strings.Contains(name, "/") {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil, fmt.Errorf("%s: %w", dir, github.ErrFileNotFound)
+	}
+	return names, nil
+}
+
+func TestAnalyze(t *testing.T) {
+	source := files{
+		"b1:cache/cache.go":   base,
+		"h1:cache/lru.go":     head,
+		"h1:metrics/stats.go": "package metrics\n\nfunc Mean(xs []float64) float64 { return 0 }\n",
+		"b1:store/store.go":   "package store\n",
+		"h1:store/store.go":   "package store\n",
+		"h1:store/extra.go":   "package store\n\nfunc helper() {}\n",
+	}
+	pr := &github.PrData{
+		Number: 4, BaseSHA: "b1", HeadSHA: "h1",
+		Files: []github.FileChange{
+			{Path: "cache/lru.go", PreviousPath: "cache/cache.go", Status: "renamed"},
+			{Path: "metrics/stats.go", Status: "added"},
+			{Path: "store/extra.go", Status: "added"}, // A new file in an existing package
+			{Path: "vendor/x/y.go", Status: "modified"},
+			{Path: "README.md", Status: "modified"},
+		},
+	}
+
+	c, err := complexity.Analyze(context.Background(), source, pr)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if c.Files != 3 || c.CyclomaticDelta != 4 || c.ChangedFunctions != 5 || c.ExportedAPIChanges != 3 || c.NewPackages != 1 {
+		t.Errorf("Unexpected complexity %+v", c)
+	}
+
+	pr.Files = append(pr.Files, github.FileChange{Path: "missing.go", Status: "modified"})
+	if skipped, err := complexity.Analyze(context.Background(), source, pr); err != nil || skipped.Files != 3 || skipped.ChangedFunctions != 5 {
+		t.Errorf("Expected a changed file that can't be fetched to be skipped, got %+v (err %v)", skipped, err)
+	}
+	if _, err := complexity.Analyze(context.Background(), source, &github.PrData{Number: 5}); err == nil {
+		t.Error("Expected an error without base and head commits")
+	}
+}
@@ -0,0 +1,112 @@
+// Package dataset reads and writes snapshots of fetched pull requests as versioned JSONL,
+// so analyses can be re-run later without calling the code host.
+package dataset
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// Format identifies pr-effort-estimator datasets in the header line.
+const Format = "pr-effort-estimator/prs"
+
+// Version is the current dataset schema version. Readers accept any version up to this one.
+const Version = 1
+
+// Header is the first line of every dataset file; each following line holds one PrData.
+type Header struct {
+	Format     string    `json:"format"`
+	Version    int       `json:"version"`
+	Source     string    `json:"source"` // e.g., "owner/repo" or a GitLab project path
+	ExportedAt time.Time `json:"exported_at"`
+	Count      int       `json:"count"`
+}
+
+// Write encodes the header followed by one JSON line per pull request.
+func Write(w io.Writer, source string, prs []*github.PrData) error {
+	enc := json.NewEncoder(w)
+	header := Header{
+		Format:     Format,
+		Version:    Version,
+		Source:     source,
+		ExportedAt: time.Now().UTC(),
+		Count:      len(prs),
+	}
+	if err := enc.Encode(header); err != nil {
+		return fmt.Errorf("writing dataset header: %w", err)
+	}
+	for _, pr := range prs {
+		if err := enc.Encode(pr); err != nil {
+			return fmt.Errorf("writing PR #%d: %w", pr.Number, err)
+		}
+	}
+	return nil
+}
+
+// Read decodes a dataset, rejecting files of another format or a newer schema version.
+func Read(r io.Reader) (*Header, []*github.PrData, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024) // PRs with long timelines make long lines
+
+	if !scanner.Scan() {
+		if err := scanner.Err(); err != nil {
+			return nil, nil, err
+		}
+		return nil, nil, fmt.Errorf("empty dataset")
+	}
+	var header Header
+	if err := json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, nil, fmt.Errorf("reading dataset header: %w", err)
+	}
+	if header.Format != Format {
+		return nil, nil, fmt.Errorf("unsupported dataset format %q", header.Format)
+	}
+	if header.Version < 1 || header.Version > Version {
+		return nil, nil, fmt.Errorf("unsupported dataset version %d (this build reads up to %d)", header.Version, Version)
+	}
+
+	var prs []*github.PrData
+	for line := 2; scanner.Scan(); line++ {
+		if len(scanner.Bytes()) == 0 {
+			continue
+		}
+		pr := &github.PrData{}
+		if err := json.Unmarshal(scanner.Bytes(), pr); err != nil {
+			return nil, nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		prs = append(prs, pr)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, err
+	}
+	return &header, prs, nil
+}
+
+// WriteFile writes a dataset to path, replacing any existing file.
+func WriteFile(path, source string, prs []*github.PrData) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := Write(f, source, prs); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// ReadFile reads a dataset from path.
+func ReadFile(path string) (*Header, []*github.PrData, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	return Read(f)
+}
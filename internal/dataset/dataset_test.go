@@ -0,0 +1,81 @@
+package dataset_test
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/dataset"
+)
+
+func TestWriteReadRoundTrip(t *testing.T) {
+	created := time.Date(2025, 8, 4, 9, 0, 0, 0, time.UTC)
+	merged := created.Add(26 * time.Hour)
+	reviewed := created.Add(3 * time.Hour)
+	prs := []*github.PrData{
+		{
+			Number:          42,
+			Title:           "Add export",
+			State:           "closed",
+			Author:          "alice",
+			CreatedAt:       created,
+			MergedAt:        &merged,
+			ClosedAt:        &merged,
+			Additions:       120,
+			Deletions:       8,
+			ChangedFiles:    4,
+			FirstReviewedAt: &reviewed,
+			Labels:          []string{"feature"},
+			Reviews:         []github.Review{{Reviewer: "bob", State: "APPROVED", SubmittedAt: reviewed}},
+			Timeline:        []github.TimelineEvent{{Event: "review_requested", Actor: "alice", Subject: "bob", CreatedAt: created.Add(time.Minute)}},
+		},
+		{Number: 43, Title: "Unmerged", State: "closed", CreatedAt: created},
+	}
+
+	path := filepath.Join(t.TempDir(), "prs.jsonl")
+	if err := dataset.WriteFile(path, "octo/repo", prs); err != nil {
+		t.Fatalf("WriteFile failed: %v", err)
+	}
+	header, got, err := dataset.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile failed: %v", err)
+	}
+
+	if header.Source != "octo/repo" || header.Version != dataset.Version || header.Count != 2 {
+		t.Errorf("Unexpected header: %+v", header)
+	}
+	if len(got) != 2 {
+		t.Fatalf("Expected 2 PRs, got %d", len(got))
+	}
+	if got[0].MergedAt == nil || !got[0].MergedAt.Equal(merged) {
+		t.Errorf("Expected MergedAt %v, got %v", merged, got[0].MergedAt)
+	}
+	if len(got[0].Reviews) != 1 || got[0].Reviews[0].Reviewer != "bob" {
+		t.Errorf("Expected reviews to round-trip, got %+v", got[0].Reviews)
+	}
+	if len(got[0].Timeline) != 1 || got[0].Timeline[0].Subject != "bob" {
+		t.Errorf("Expected timeline to round-trip, got %+v", got[0].Timeline)
+	}
+	if got[1].MergedAt != nil || got[1].FirstReviewedAt != nil {
+		t.Errorf("Expected nil timestamps to stay nil, got %+v", got[1])
+	}
+}
+
+func TestRead_RejectsNewerVersion(t *testing.T) {
+	input := `{"format":"pr-effort-estimator/prs","version":99}` + "\n"
+	_, _, err := dataset.Read(strings.NewReader(input))
+	if err == nil || !strings.Contains(err.Error(), "unsupported dataset version 99") {
+		t.Errorf("Expected an unsupported version error, got %v", err)
+	}
+}
+
+func TestRead_RejectsForeignFormat(t *testing.T) {
+	var buf bytes.Buffer
+	buf.WriteString(`{"number":1}` + "\n")
+	if _, _, err := dataset.Read(&buf); err == nil {
+		t.Error("Expected an error for a file without a dataset header, but got none")
+	}
+}
@@ -0,0 +1,207 @@
+// Package exporter publishes review latency metrics in the Prometheus text exposition format.
+package exporter
+
+import (
+	"bufio"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/server"
+)
+
+// Buckets are the histogram upper bounds, from one hour to four weeks.
+var Buckets = []time.Duration{
+	time.Hour,
+	4 * time.Hour,
+	8 * time.Hour,
+	24 * time.Hour,
+	2 * 24 * time.Hour,
+	3 * 24 * time.Hour,
+	7 * 24 * time.Hour,
+	14 * 24 * time.Hour,
+	28 * 24 * time.Hour,
+}
+
+// Exporter renders the store's latest snapshots on every scrape. The store refreshes them
+// in the background, so scrapes never hit the code host.
+type Exporter struct {
+	store *server.Store
+	now   func() time.Time
+}
+
+func New(store *server.Store) *Exporter {
+	return &Exporter{store: store, now: time.Now}
+}
+
+func (e *Exporter) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	bw := bufio.NewWriter(w)
+	e.write(bw)
+	if err := bw.Flush(); err != nil {
+		log.Printf("Warning: Could not write metrics: %v", err)
+	}
+}
+
+// family collects the samples of one metric so that each family is written as a single block.
+type family struct {
+	name, help, kind string
+	lines            []string
+}
+
+func (f *family) gauge(labels []string, value float64) {
+	f.lines = append(f.lines, f.name+formatLabels(labels)+" "+formatFloat(value))
+}
+
+// histogram adds cumulative buckets, sum and count of durations in seconds.
+func (f *family) histogram(labels []string, durations []time.Duration) {
+	var sum float64
+	counts := make([]int, len(Buckets))
+	for _, d := range durations {
+		sum += d.Seconds()
+		for i, bound := range Buckets {
+			if d <= bound {
+				counts[i]++
+			}
+		}
+	}
+	for i, bound := range Buckets {
+		le := append(append([]string{}, labels...), "le", formatFloat(bound.Seconds()))
+		f.lines = append(f.lines, f.name+"_bucket"+formatLabels(le)+" "+strconv.Itoa(counts[i]))
+	}
+	inf := append(append([]string{}, labels...), "le", "+Inf")
+	f.lines = append(f.lines,
+		f.name+"_bucket"+formatLabels(inf)+" "+strconv.Itoa(len(durations)),
+		f.name+"_sum"+formatLabels(labels)+" "+formatFloat(sum),
+		f.name+"_count"+formatLabels(labels)+" "+strconv.Itoa(len(durations)),
+	)
+}
+
+func (e *Exporter) write(w *bufio.Writer) {
+	now := e.now()
+	firstReview := &family{name: "pr_effort_time_to_first_review_seconds", kind: "histogram",
+		help: "Time from PR creation to the first review."}
+	merge := &family{name: "pr_effort_time_to_merge_seconds", kind: "histogram",
+		help: "Time from PR creation to merge, for merged PRs."}
+	labelFirstReview := &family{name: "pr_effort_label_time_to_first_review_seconds", kind: "histogram",
+		help: "Time from PR creation to the first review, per PR label. PRs with several labels count once per label."}
+	labelMerge := &family{name: "pr_effort_label_time_to_merge_seconds", kind: "histogram",
+		help: "Time from PR creation to merge per PR label, for merged PRs. PRs with several labels count once per label."}
+	openAge := &family{name: "pr_effort_open_pr_age_seconds", kind: "histogram",
+		help: "Age of open PRs."}
+	openPrs := &family{name: "pr_effort_open_prs", kind: "gauge",
+		help: "Number of open PRs."}
+	labelOpenPrs := &family{name: "pr_effort_label_open_prs", kind: "gauge",
+		help: "Number of open PRs per PR label."}
+	oldest := &family{name: "pr_effort_oldest_open_pr_age_seconds", kind: "gauge",
+		help: "Age of the oldest open PR."}
+	refreshed := &family{name: "pr_effort_last_refresh_timestamp_seconds", kind: "gauge",
+		help: "Unix time of the last successful refresh."}
+
+	for _, repo := range e.store.Repos() {
+		snap, ok := e.store.Snapshot(repo)
+		if !ok {
+			continue
+		}
+		repoLabels := []string{"repo", repo}
+
+		var reviews, merges, ages []time.Duration
+		byLabel := make(map[string]*labelSamples)
+		for _, m := range snap.Metrics {
+			open := m.State == "open"
+			var age time.Duration
+			if open {
+				age = now.Sub(m.CreatedAt)
+				ages = append(ages, age)
+			}
+			review, merged := metrics.FirstReviewSelector(m), metrics.MergeSelector(m)
+			if review > 0 {
+				reviews = append(reviews, review)
+			}
+			if merged > 0 {
+				merges = append(merges, merged)
+			}
+			for _, label := range m.Labels {
+				s := byLabel[label]
+				if s == nil {
+					s = &labelSamples{}
+					byLabel[label] = s
+				}
+				if review > 0 {
+					s.reviews = append(s.reviews, review)
+				}
+				if merged > 0 {
+					s.merges = append(s.merges, merged)
+				}
+				if open {
+					s.open++
+				}
+			}
+		}
+
+		firstReview.histogram(repoLabels, reviews)
+		merge.histogram(repoLabels, merges)
+		openAge.histogram(repoLabels, ages)
+		openPrs.gauge(repoLabels, float64(len(ages)))
+		var maxAge time.Duration
+		for _, age := range ages {
+			maxAge = max(maxAge, age)
+		}
+		oldest.gauge(repoLabels, maxAge.Seconds())
+		refreshed.gauge(repoLabels, float64(snap.RefreshedAt.Unix()))
+
+		labels := make([]string, 0, len(byLabel))
+		for label := range byLabel {
+			labels = append(labels, label)
+		}
+		sort.Strings(labels)
+		for _, label := range labels {
+			s := byLabel[label]
+			ls := []string{"repo", repo, "label", label}
+			labelFirstReview.histogram(ls, s.reviews)
+			labelMerge.histogram(ls, s.merges)
+			labelOpenPrs.gauge(ls, float64(s.open))
+		}
+	}
+
+	for _, f := range []*family{firstReview, merge, labelFirstReview, labelMerge, openAge, openPrs, labelOpenPrs, oldest, refreshed} {
+		fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s %s\n", f.name, f.help, f.name, f.kind)
+		for _, line := range f.lines {
+			w.WriteString(line)
+			w.WriteByte('\n')
+		}
+	}
+}
+
+type labelSamples struct {
+	reviews, merges []time.Duration
+	open            int
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// formatLabels renders name/value pairs as {name="value",...}.
+func formatLabels(pairs []string) string {
+	if len(pairs) == 0 {
+		return ""
+	}
+	var b strings.Builder
+	b.WriteByte('{')
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteString(pairs[i] + `="` + labelValueEscaper.Replace(pairs[i+1]) + `"`)
+	}
+	b.WriteByte('}')
+	return b.String()
+}
+
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,60 @@
+package exporter_test
+
+import (
+	"context"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/exporter"
+	"github.com/sushant-115/pr-effort-estimator/internal/server"
+)
+
+func TestExporter(t *testing.T) {
+	now := time.Now()
+	created := now.Add(-100 * time.Hour)
+	reviewed := created.Add(2 * time.Hour)
+	merged := created.Add(30 * time.Hour)
+	prs := []*github.PrData{
+		{Number: 1, State: "closed", CreatedAt: created, FirstReviewedAt: &reviewed, MergedAt: &merged, Labels: []string{"bug"}},
+		{Number: 2, State: "open", CreatedAt: now.Add(-3 * time.Hour), Labels: []string{"bug", `say "hi"`}},
+		{Number: 3, State: "open", CreatedAt: now.Add(-50 * time.Hour)},
+	}
+	store := server.NewStore([]string{"octo/repo"}, func(ctx context.Context, repo string) ([]*github.PrData, error) {
+		return prs, nil
+	})
+	if err := store.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	rec := httptest.NewRecorder()
+	exporter.New(store).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+	out := string(body)
+
+	if ct := rec.Header().Get("Content-Type"); !strings.HasPrefix(ct, "text/plain; version=0.0.4") {
+		t.Errorf("Unexpected content type %q", ct)
+	}
+	for _, want := range []string{
+		"# TYPE pr_effort_time_to_first_review_seconds histogram",
+		`pr_effort_time_to_first_review_seconds_bucket{repo="octo/repo",le="3600"} 0`,
+		`pr_effort_time_to_first_review_seconds_bucket{repo="octo/repo",le="14400"} 1`,
+		`pr_effort_time_to_first_review_seconds_sum{repo="octo/repo"} 7200`,
+		`pr_effort_time_to_merge_seconds_bucket{repo="octo/repo",le="+Inf"} 1`,
+		`pr_effort_time_to_merge_seconds_sum{repo="octo/repo"} 108000`,
+		`pr_effort_label_time_to_merge_seconds_count{repo="octo/repo",label="bug"} 1`,
+		`pr_effort_open_pr_age_seconds_bucket{repo="octo/repo",le="14400"} 1`,
+		`pr_effort_open_pr_age_seconds_count{repo="octo/repo"} 2`,
+		`pr_effort_open_prs{repo="octo/repo"} 2`,
+		`pr_effort_label_open_prs{repo="octo/repo",label="bug"} 1`,
+		`pr_effort_label_open_prs{repo="octo/repo",label="say \"hi\""} 1`,
+		"# TYPE pr_effort_oldest_open_pr_age_seconds gauge",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+// Package features derives per-PR features, such as languages and the share of tests, from the
+// changed files of pull requests.
+package features
+
+import (
+	"path"
+	"strings"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+// Other is the language of files with unknown extensions.
+const Other = "Other"
+
+var languages = map[string]string{
+	".go": "Go", ".py": "Python", ".js": "JavaScript", ".jsx": "JavaScript", ".mjs": "JavaScript", ".cjs": "JavaScript",
+	".ts": "TypeScript", ".tsx": "TypeScript", ".java": "Java", ".kt": "Kotlin", ".kts": "Kotlin", ".scala": "Scala",
+	".rb": "Ruby", ".rs": "Rust", ".c": "C", ".h": "C", ".cc": "C++", ".cpp": "C++", ".cxx": "C++", ".hpp": "C++",
+	".cs": "C#", ".php": "PHP", ".swift": "Swift", ".m": "Objective-C", ".dart": "Dart", ".ex": "Elixir", ".exs": "Elixir",
+	".sh": "Shell", ".bash": "Shell", ".sql": "SQL", ".proto": "Protocol Buffers", ".tf": "Terraform",
+	".html": "HTML", ".css": "CSS", ".scss": "CSS", ".vue": "Vue", ".md": "Markdown", ".rst": "reStructuredText",
+	".yaml": "YAML", ".yml": "YAML", ".json": "JSON", ".toml": "TOML", ".xml": "XML",
+}
+
+// languageFiles names languages of files identified by name rather than extension.
+var languageFiles = map[string]string{"Dockerfile": "Dockerfile", "Makefile": "Makefile", "go.mod": "Go", "go.sum": "Go"}
+
+// configExtensions and configFiles identify configuration rather than code.
+var (
+	configExtensions = map[string]bool{".yaml": true, ".yml": true, ".json": true, ".toml": true, ".ini": true, ".cfg": true,
+		".conf": true, ".properties": true, ".env": true, ".tf": true, ".tfvars": true, ".xml": true}
+	configFiles = map[string]bool{"Dockerfile": true, "Makefile": true, "go.mod": true, "package.json": true, "requirements.txt": true,
+		".gitignore": true, ".gitattributes": true, ".editorconfig": true, ".dockerignore": true, "CODEOWNERS": true}
+)
+
+// generatedDirs hold vendored code; generatedPatterns match generated sources and lock files by name.
+var (
+	generatedDirs     = map[string]bool{"vendor": true, "node_modules": true, "third_party": true, "Pods": true}
+	generatedPatterns = []string{"*.pb.go", "*.pb.gw.go", "*_gen.go", "*_generated.go", "zz_generated*", "*.generated.*",
+		"*_pb2.py", "*_pb2_grpc.py", "*.min.js", "*.min.css", "*.snap", "go.sum", "package-lock.json", "yarn.lock",
+		"pnpm-lock.yaml", "Cargo.lock", "Gemfile.lock", "poetry.lock", "composer.lock", "Pipfile.lock"}
+)
+
+// testDirs hold tests and test data.
+var testDirs = map[string]bool{"test": true, "tests": true, "__tests__": true, "spec": true, "testdata": true, "fixtures": true}
+
+// Extractor derives features from changed files.
+type Extractor struct {
+	excludeGenerated bool
+	patterns         []string
+}
+
+func NewExtractor(cfg *config.FeaturesConfig) *Extractor {
+	return &Extractor{
+		excludeGenerated: cfg.ExcludeGenerated,
+		patterns:         append(append([]string(nil), generatedPatterns...), cfg.GeneratedPatterns...),
+	}
+}
+
+// Apply sets the features of PRs with file data and, when configured, leaves generated files out
+// of their size. PRs whose size already excludes generated files are not shrunk twice, and Go
+// complexity analyzed before is kept.
+func (e *Extractor) Apply(prs []*github.PrData) {
+	for _, pr := range prs {
+		if len(pr.Files) == 0 {
+			continue
+		}
+		previous := pr.Features
+		pr.Features = e.Extract(pr.Files)
+		if previous != nil {
+			pr.Features.GoComplexity = previous.GoComplexity
+		}
+		if previous != nil && previous.SizeExcludesGenerated {
+			pr.Features.SizeExcludesGenerated = true
+			continue
+		}
+		if !e.excludeGenerated {
+			continue
+		}
+		for _, f := range pr.Files {
+			if e.IsGenerated(f.Path) {
+				pr.Additions = max(pr.Additions-f.Additions, 0)
+				pr.Deletions = max(pr.Deletions-f.Deletions, 0)
+				pr.ChangedFiles = max(pr.ChangedFiles-1, 0)
+			}
+		}
+		pr.Features.SizeExcludesGenerated = true
+	}
+}
+
+// Extract derives the features of a change from its files.
+func (e *Extractor) Extract(files []github.FileChange) *github.Features {
+	features := &github.Features{Languages: make(map[string]int)}
+	dirs := make(map[string]bool)
+	configs, code := 0, 0
+	for _, f := range files {
+		dirs[path.Dir(f.Path)] = true
+		lines := f.Additions + f.Deletions
+		if e.IsGenerated(f.Path) {
+			features.GeneratedFiles++
+			features.GeneratedLines += lines
+			continue
+		}
+		features.Languages[Language(f.Path)] += lines
+		if IsTest(f.Path) {
+			features.TestFiles++
+		}
+		if IsConfig(f.Path) {
+			configs++
+		} else {
+			code++
+		}
+	}
+	features.ConfigOnly = configs > 0 && code == 0
+	features.Directories = len(dirs)
+	if len(files) > 0 {
+		features.TestFileShare = float64(features.TestFiles) / float64(len(files))
+	}
+	return features
+}
+
+// Language names the language of a file by its extension or name, or returns Other.
+func Language(p string) string {
+	base := path.Base(p)
+	if lang, ok := languageFiles[base]; ok {
+		return lang
+	}
+	if lang, ok := languages[strings.ToLower(path.Ext(base))]; ok {
+		return lang
+	}
+	return Other
+}
+
+// IsTest reports whether a file is a test or test data, by naming convention or directory.
+func IsTest(p string) bool {
+	base := path.Base(p)
+	name := strings.TrimSuffix(base, path.Ext(base))
+	if strings.HasSuffix(name, "_test") || strings.HasPrefix(name, "test_") || strings.HasSuffix(name, "Test") ||
+		strings.HasSuffix(name, "Tests") || strings.HasSuffix(name, ".test") || strings.HasSuffix(name, ".spec") {
+		return true
+	}
+	return inDir(p, testDirs)
+}
+
+// IsConfig reports whether a file configures builds, deployments or tools rather than holding code.
+func IsConfig(p string) bool {
+	base := path.Base(p)
+	return configFiles[base] || configExtensions[strings.ToLower(path.Ext(base))] || strings.HasPrefix(p, ".github/")
+}
+
+// IsGenerated reports whether a file is vendored, generated or a lock file.
+func (e *Extractor) IsGenerated(p string) bool {
+	if inDir(p, generatedDirs) {
+		return true
+	}
+	base := path.Base(p)
+	for _, pattern := range e.patterns {
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+		if ok, _ := path.Match(pattern, p); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// inDir reports whether any directory of p is one of dirs.
+func inDir(p string, dirs map[string]bool) bool {
+	parts := strings.Split(path.Dir(p), "/")
+	for _, part := range parts {
+		if dirs[part] {
+			return true
+		}
+	}
+	return false
+}
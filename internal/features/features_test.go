@@ -0,0 +1,87 @@
+package features_test
+
+import (
+	"testing"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/features"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+func TestExtract(t *testing.T) {
+	e := features.NewExtractor(&config.FeaturesConfig{GeneratedPatterns: []string{"api/openapi/*"}})
+	f := e.Extract([]github.FileChange{
+		{Path: "internal/metrics/trend.go", Additions: 40, Deletions: 10},
+		{Path: "internal/metrics/trend_test.go", Additions: 30},
+		{Path: "web/src/__tests__/app.tsx", Additions: 5},
+		{Path: "api/gen/client.pb.go", Additions: 500},
+		{Path: "vendor/github.com/x/y.go", Additions: 100},
+		{Path: "api/openapi/spec.yaml", Additions: 20},
+		{Path: "go.sum", Additions: 4, Deletions: 2},
+	})
+
+	if f.GeneratedFiles != 4 || f.GeneratedLines != 626 {
+		t.Errorf("Expected 4 generated files with 626 lines, got %d with %d", f.GeneratedFiles, f.GeneratedLines)
+	}
+	if f.Languages["Go"] != 80 || f.Languages["TypeScript"] != 5 || len(f.Languages) != 2 {
+		t.Errorf("Unexpected languages %v", f.Languages)
+	}
+	if f.TestFiles != 2 || f.TestFileShare != 2.0/7 {
+		t.Errorf("Expected 2 of 7 files to be tests, got %d (%v)", f.TestFiles, f.TestFileShare)
+	}
+	if f.Directories != 6 || f.ConfigOnly {
+		t.Errorf("Expected 6 directories and code changes, got %d (config only %v)", f.Directories, f.ConfigOnly)
+	}
+
+	configOnly := e.Extract([]github.FileChange{{Path: ".github/workflows/ci.yml"}, {Path: "Dockerfile"}, {Path: "package-lock.json"}})
+	if !configOnly.ConfigOnly {
+		t.Error("Expected workflow and Dockerfile changes to be config only")
+	}
+}
+
+func TestLanguage(t *testing.T) {
+	for path, want := range map[string]string{
+		"main.go":           "Go",
+		"web/App.TSX":       "TypeScript",
+		"build/Dockerfile":  "Dockerfile",
+		"scripts/deploy.sh": "Shell",
+		"LICENSE":           features.Other,
+		"src/lib/model.cpp": "C++",
+	} {
+		if got := features.Language(path); got != want {
+			t.Errorf("Language(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
+
+func TestApply_ExcludeGenerated(t *testing.T) {
+	pr := &github.PrData{
+		Additions: 520, Deletions: 10, ChangedFiles: 2,
+		Files: []github.FileChange{
+			{Path: "server.go", Additions: 20, Deletions: 10},
+			{Path: "server.pb.go", Additions: 500},
+		},
+	}
+	noFiles := &github.PrData{Additions: 50, ChangedFiles: 3}
+
+	e := features.NewExtractor(&config.FeaturesConfig{ExcludeGenerated: true})
+	e.Apply([]*github.PrData{pr, noFiles})
+	pr.Features.GoComplexity = &github.GoComplexity{ChangedFunctions: 2}
+	e.Apply([]*github.PrData{pr}) // Already excluded, e.g. when reading an exported dataset
+
+	if pr.Additions != 20 || pr.Deletions != 10 || pr.ChangedFiles != 1 || !pr.Features.SizeExcludesGenerated {
+		t.Errorf("Expected size +20 / -10 in 1 file without generated code, got +%d / -%d in %d files", pr.Additions, pr.Deletions, pr.ChangedFiles)
+	}
+	if pr.Features.GoComplexity == nil || pr.Features.GoComplexity.ChangedFunctions != 2 {
+		t.Errorf("Expected Go complexity to be kept, got %+v", pr.Features.GoComplexity)
+	}
+	if noFiles.Features != nil || noFiles.Additions != 50 {
+		t.Errorf("Expected PRs without file data to be left alone, got %+v", noFiles)
+	}
+
+	kept := &github.PrData{Additions: 520, Files: pr.Files}
+	features.NewExtractor(&config.FeaturesConfig{}).Apply([]*github.PrData{kept})
+	if kept.Additions != 520 || kept.Features.SizeExcludesGenerated || kept.Features.GeneratedFiles != 1 {
+		t.Errorf("Expected size to include generated files by default, got %+v", kept)
+	}
+}
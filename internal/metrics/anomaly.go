@@ -0,0 +1,212 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// AnomalyOptions configures change-point and outlier detection.
+type AnomalyOptions struct {
+	// ChangeThreshold is the critical value of the normalized CUSUM statistic. 1.36 corresponds to
+	// a 5% false alarm rate for a single change in a series without one.
+	ChangeThreshold float64
+	// MinSegment is the minimum number of PRs on each side of a change point.
+	MinSegment int
+	// OutlierScore is the robust z-score above which a PR is an outlier. 3.5 is the usual cutoff
+	// for the modified z-score of Iglewicz and Hoaglin.
+	OutlierScore float64
+}
+
+// DefaultAnomalyOptions are conservative defaults that rarely flag noise.
+var DefaultAnomalyOptions = AnomalyOptions{ChangeThreshold: 1.36, MinSegment: 5, OutlierScore: 3.5}
+
+// ChangePoint is a shift in the typical duration of a metric over time.
+type ChangePoint struct {
+	At           time.Time // When the first PR after the change was reviewed or merged
+	Before       time.Duration
+	After        time.Duration
+	BeforeCount  int
+	AfterCount   int
+	Change       float64 // Relative change of the median, e.g. 1.5 = 150% slower
+	Significance float64 // Normalized CUSUM statistic; higher is a clearer change
+}
+
+// Outlier is a PR whose duration is unusual compared to PRs of similar size.
+type Outlier struct {
+	Pr       *PrMetrics
+	At       time.Time
+	Duration time.Duration
+	Median   time.Duration // Median duration of the PRs it is compared with
+	BasedOn  string        // "size bucket M" or "all PRs" when the bucket has too few samples
+	Score    float64       // Robust z-score of the log duration; positive is slower than usual
+}
+
+type sample struct {
+	pr *PrMetrics
+	at time.Time
+	d  time.Duration
+}
+
+// completed returns the PRs with a duration, ordered by when the review or merge happened.
+func completed(history []*PrMetrics, selector func(*PrMetrics) time.Duration) []sample {
+	var samples []sample
+	for _, m := range history {
+		if d := selector(m); d > 0 {
+			samples = append(samples, sample{pr: m, at: m.CreatedAt.Add(d), d: d})
+		}
+	}
+	sort.SliceStable(samples, func(i, j int) bool { return samples[i].at.Before(samples[j].at) })
+	return samples
+}
+
+// DetectChangePoints finds shifts in the level of a duration over time by binary segmentation with
+// a CUSUM statistic on log durations. The noise level is estimated from successive differences, so
+// it isn't inflated by the shifts being searched for.
+func DetectChangePoints(history []*PrMetrics, selector func(*PrMetrics) time.Duration, opts AnomalyOptions) []ChangePoint {
+	samples := completed(history, selector)
+	minSegment := max(opts.MinSegment, 2)
+	if len(samples) < 2*minSegment {
+		return nil
+	}
+
+	logs := make([]float64, len(samples))
+	for i, s := range samples {
+		logs[i] = math.Log(s.d.Hours())
+	}
+	diffs := make([]float64, len(logs)-1)
+	for i := range diffs {
+		diffs[i] = math.Abs(logs[i+1] - logs[i])
+	}
+	// For independent normal noise, |x[i+1]-x[i]| has median 0.6745*sqrt(2)*sigma
+	sigma := median(diffs) / (0.6745 * math.Sqrt2)
+	if sigma == 0 {
+		return nil
+	}
+
+	var splits []int
+	significance := make(map[int]float64)
+	var segment func(lo, hi int)
+	segment = func(lo, hi int) {
+		n := hi - lo
+		if n < 2*minSegment {
+			return
+		}
+		mean := 0.0
+		for _, v := range logs[lo:hi] {
+			mean += v
+		}
+		mean /= float64(n)
+
+		best, bestStat, cusum := -1, 0.0, 0.0
+		for k := lo; k < hi-1; k++ {
+			cusum += logs[k] - mean
+			if split := k + 1; split-lo >= minSegment && hi-split >= minSegment {
+				if stat := math.Abs(cusum) / (sigma * math.Sqrt(float64(n))); stat > bestStat {
+					best, bestStat = split, stat
+				}
+			}
+		}
+		if best < 0 || bestStat < opts.ChangeThreshold {
+			return
+		}
+		splits = append(splits, best)
+		significance[best] = bestStat
+		segment(lo, best)
+		segment(best, hi)
+	}
+	segment(0, len(samples))
+	sort.Ints(splits)
+
+	var points []ChangePoint
+	bounds := append(append([]int{0}, splits...), len(samples))
+	for i, split := range splits {
+		before := durations(samples[bounds[i]:split])
+		after := durations(samples[split:bounds[i+2]])
+		p := ChangePoint{
+			At:           samples[split].at,
+			Before:       Summarize(before).P50,
+			After:        Summarize(after).P50,
+			BeforeCount:  len(before),
+			AfterCount:   len(after),
+			Significance: significance[split],
+		}
+		p.Change = float64(p.After-p.Before) / float64(p.Before)
+		points = append(points, p)
+	}
+	return points
+}
+
+// DetectOutliers flags PRs whose duration has a robust z-score (based on the median and median
+// absolute deviation of log durations) beyond opts.OutlierScore among PRs of the same size bucket,
+// falling back to all PRs when the bucket has fewer than MinBucketSamples. Outliers are ordered by
+// descending absolute score.
+func DetectOutliers(history []*PrMetrics, selector func(*PrMetrics) time.Duration, opts AnomalyOptions) []Outlier {
+	samples := completed(history, selector)
+	groups := map[string][]sample{"all PRs": samples}
+	for _, s := range samples {
+		key := "size bucket " + SizeBucket(s.pr.Additions, s.pr.Deletions)
+		groups[key] = append(groups[key], s)
+	}
+
+	var outliers []Outlier
+	for _, s := range samples {
+		basedOn := "size bucket " + SizeBucket(s.pr.Additions, s.pr.Deletions)
+		if len(groups[basedOn]) < MinBucketSamples {
+			basedOn = "all PRs"
+		}
+		group := groups[basedOn]
+		if len(group) < MinBucketSamples {
+			continue
+		}
+
+		logs := make([]float64, len(group))
+		for i, g := range group {
+			logs[i] = math.Log(g.d.Hours())
+		}
+		center := median(logs)
+		deviations := make([]float64, len(logs))
+		for i, v := range logs {
+			deviations[i] = math.Abs(v - center)
+		}
+		mad := median(deviations)
+		if mad == 0 {
+			continue
+		}
+
+		score := 0.6745 * (math.Log(s.d.Hours()) - center) / mad
+		if math.Abs(score) >= opts.OutlierScore {
+			outliers = append(outliers, Outlier{
+				Pr:       s.pr,
+				At:       s.at,
+				Duration: s.d,
+				Median:   time.Duration(math.Exp(center) * float64(time.Hour)).Round(time.Second),
+				BasedOn:  basedOn,
+				Score:    score,
+			})
+		}
+	}
+	sort.SliceStable(outliers, func(i, j int) bool { return math.Abs(outliers[i].Score) > math.Abs(outliers[j].Score) })
+	return outliers
+}
+
+func durations(samples []sample) []time.Duration {
+	ds := make([]time.Duration, len(samples))
+	for i, s := range samples {
+		ds[i] = s.d
+	}
+	return ds
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
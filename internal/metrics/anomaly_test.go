@@ -0,0 +1,67 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// reviewedDaily returns PRs created one per day, each reviewed after the given number of hours.
+func reviewedDaily(start time.Time, hours []float64) []*metrics.PrMetrics {
+	var ms []*metrics.PrMetrics
+	for i, h := range hours {
+		ms = append(ms, &metrics.PrMetrics{
+			Number:            i + 1,
+			CreatedAt:         start.AddDate(0, 0, i),
+			TimeToFirstReview: time.Duration(h * float64(time.Hour)),
+			Additions:         20,
+		})
+	}
+	return ms
+}
+
+func TestDetectChangePoints(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	hours := []float64{2, 3, 2.5, 4, 3, 2, 3.5, 2, 3, 2.5, 3, 2}
+	// Reviews become roughly ten times slower from the 13th PR on
+	hours = append(hours, 30, 25, 40, 28, 35, 30, 22, 38, 30, 26)
+
+	points := metrics.DetectChangePoints(reviewedDaily(start, hours), metrics.FirstReviewSelector, metrics.DefaultAnomalyOptions)
+	if len(points) != 1 {
+		t.Fatalf("Expected a single change point, got %+v", points)
+	}
+	p := points[0]
+	if p.BeforeCount != 12 || p.AfterCount != 10 {
+		t.Errorf("Expected the change after the 12th PR, got %d before and %d after", p.BeforeCount, p.AfterCount)
+	}
+	if !p.At.Equal(start.AddDate(0, 0, 12).Add(30 * time.Hour)) {
+		t.Errorf("Unexpected change time %v", p.At)
+	}
+	if p.Before != 2*time.Hour+45*time.Minute || p.After != 30*time.Hour || p.Change < 9 {
+		t.Errorf("Unexpected magnitude: %v -> %v (%+.0f%%)", p.Before, p.After, p.Change*100)
+	}
+
+	stable := []float64{2, 3, 2.5, 4, 3, 2, 3.5, 2, 3, 2.5, 3, 2, 3, 2.5, 4, 2}
+	if points := metrics.DetectChangePoints(reviewedDaily(start, stable), metrics.FirstReviewSelector, metrics.DefaultAnomalyOptions); len(points) != 0 {
+		t.Errorf("Expected no change point in a stable series, got %+v", points)
+	}
+}
+
+func TestDetectOutliers(t *testing.T) {
+	start := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	history := reviewedDaily(start, []float64{2, 3, 2.5, 4, 3, 2, 3.5, 80})
+	// Large PRs taking long are expected for their size and must not be flagged
+	for i, h := range []float64{60, 90, 70, 80, 75} {
+		history = append(history, &metrics.PrMetrics{Number: 100 + i, CreatedAt: start, TimeToFirstReview: time.Duration(h) * time.Hour, Additions: 2000})
+	}
+
+	outliers := metrics.DetectOutliers(history, metrics.FirstReviewSelector, metrics.DefaultAnomalyOptions)
+	if len(outliers) != 1 {
+		t.Fatalf("Expected one outlier, got %+v", outliers)
+	}
+	o := outliers[0]
+	if o.Pr.Number != 8 || o.BasedOn != "size bucket S" || o.Score < 3.5 || o.Duration != 80*time.Hour || o.Median != 3*time.Hour {
+		t.Errorf("Unexpected outlier: #%d %s score %.1f duration %v", o.Pr.Number, o.BasedOn, o.Score, o.Duration)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"log"
 	"math"
+	"strings"
 	"time"
 
 	"github.com/sushant-115/pr-effort-estimator/api/github"
@@ -16,6 +17,10 @@ import (
 type PrMetrics struct {
 	Number            int
 	Title             string
+	Author            string
+	Labels            []string
+	CreatedAt         time.Time
+	Merged            bool
 	TimeToFirstReview time.Duration
 	TimeToMerge       time.Duration
 	ReviewToMerge     time.Duration
@@ -23,6 +28,8 @@ type PrMetrics struct {
 	Deletions         int
 	ChangedFiles      int
 	State             string
+	ReviewRounds      int              // Review iterations such as Gerrit patch sets, 0 if unknown
+	Features          *github.Features // Derived from changed files, nil without file data
 }
 
 // NormalDistributionEstimates holds percentile estimates for a given metric.
@@ -34,6 +41,13 @@ type NormalDistributionEstimates struct {
 	P90         time.Duration // 90th percentile
 	P95         time.Duration // 95th percentile
 	SampleCount int
+	// EffectiveSampleCount is the number of equally weighted PRs the weighted sample is worth;
+	// it equals SampleCount unless PRs are weighted by recency.
+	EffectiveSampleCount float64
+	Excluded             int // PRs left out because EstimateOptions.Exclude lists them
+	// Outliers counts the observations trimmed or winsorized, or with OutliersMAD, those more than
+	// 3 scaled MADs from the median. Trimmed observations don't count toward SampleCount.
+	Outliers int
 }
 
 // CalculateMetrics computes various time-based metrics for a single PR.
@@ -41,10 +55,16 @@ func CalculateMetrics(pr *github.PrData) *PrMetrics {
 	metrics := &PrMetrics{
 		Number:       pr.Number,
 		Title:        pr.Title,
+		Author:       pr.Author,
+		Labels:       pr.Labels,
+		CreatedAt:    pr.CreatedAt,
+		Merged:       pr.MergedAt != nil,
 		Additions:    pr.Additions,
 		Deletions:    pr.Deletions,
 		ChangedFiles: pr.ChangedFiles,
 		State:        pr.State,
+		ReviewRounds: pr.ReviewRounds,
+		Features:     pr.Features,
 	}
 
 	// Calculate TimeToFirstReview
@@ -69,6 +89,11 @@ func CalculateMetrics(pr *github.PrData) *PrMetrics {
 
 // AnalyzePrs iterates through a slice of PrData, calculates metrics, and prints them.
 func AnalyzePrs(prs []*github.PrData) {
+	AnalyzePrsWithOptions(prs, EstimateOptions{})
+}
+
+// AnalyzePrsWithOptions is AnalyzePrs with the estimates weighted and limited by opts.
+func AnalyzePrsWithOptions(prs []*github.PrData, opts EstimateOptions) {
 	var allMetrics []*PrMetrics
 	var totalTimeToMerge time.Duration
 	var mergedPrCount int
@@ -84,6 +109,9 @@ func AnalyzePrs(prs []*github.PrData) {
 		} else {
 			log.Println("  Time to First Review: N/A (No reviews or PR still open)")
 		}
+		if metrics.ReviewRounds > 0 {
+			log.Printf("  Review Rounds: %d", metrics.ReviewRounds)
+		}
 
 		if metrics.State == "merged" {
 			log.Printf("  Time to Merge: %v", metrics.TimeToMerge)
@@ -111,17 +139,32 @@ func AnalyzePrs(prs []*github.PrData) {
 		log.Println("No merged PRs to calculate average time to merge.")
 	}
 
+	ReportEstimates(allMetrics, opts)
+}
+
+// ReportEstimates prints normal distribution based estimates for time to first review and time to merge,
+// weighting PRs by recency as configured by opts.
+func ReportEstimates(allMetrics []*PrMetrics, opts EstimateOptions) {
 	log.Println("\n--- Normal Distribution Based Estimates ---")
-	estimateTimeToFirstReview := EstimateTimesUsingNormalDistribution(allMetrics, func(m *PrMetrics) time.Duration {
+	estimateTimeToFirstReview := EstimateTimesWithOptions(allMetrics, func(m *PrMetrics) time.Duration {
 		return m.TimeToFirstReview
-	}, "Time to First Review")
+	}, "Time to First Review", opts)
+
+	estimateTimeToMerge := EstimateTimesWithOptions(allMetrics, MergeSelector, "Time to Merge (Merged PRs)", opts)
 
-	estimateTimeToMerge := EstimateTimesUsingNormalDistribution(allMetrics, func(m *PrMetrics) time.Duration {
-		return m.TimeToMerge
-	}, "Time to Merge (Merged PRs)")
+	if opts.Weighted() {
+		fmt.Printf("Weighting PRs by recency (half-life: %s, window: %s)\n\n", durationOrNone(opts.HalfLife), durationOrNone(opts.Window))
+	}
+	switch opts.Outliers {
+	case OutliersTrim, OutliersWinsorize:
+		lower, upper := opts.quantiles()
+		fmt.Printf("Outliers: %s at the %g and %g percentiles\n\n", opts.Outliers, lower*100, upper*100)
+	case OutliersMAD:
+		fmt.Print("Outliers: mean and standard deviation replaced by median and scaled MAD\n\n")
+	}
 
 	if estimateTimeToFirstReview.SampleCount > 0 {
-		fmt.Printf("Estimated Time to First Review (based on %d PRs):\n", estimateTimeToFirstReview.SampleCount)
+		fmt.Printf("Estimated Time to First Review (based on %s PRs%s):\n", sampleSize(estimateTimeToFirstReview), adjustments(estimateTimeToFirstReview))
 		fmt.Printf("  Mean: %v, StdDev: %v\n", estimateTimeToFirstReview.Mean, estimateTimeToFirstReview.StdDev)
 		fmt.Printf("  50th Percentile (Median): %v\n", estimateTimeToFirstReview.P50)
 		fmt.Printf("  80th Percentile: %v\n", estimateTimeToFirstReview.P80)
@@ -132,7 +175,7 @@ func AnalyzePrs(prs []*github.PrData) {
 	}
 
 	if estimateTimeToMerge.SampleCount > 0 {
-		fmt.Printf("\nEstimated Time to Merge (based on %d merged PRs):\n", estimateTimeToMerge.SampleCount)
+		fmt.Printf("\nEstimated Time to Merge (based on %s merged PRs%s):\n", sampleSize(estimateTimeToMerge), adjustments(estimateTimeToMerge))
 		fmt.Printf("  Mean: %v, StdDev: %v\n", estimateTimeToMerge.Mean, estimateTimeToMerge.StdDev)
 		fmt.Printf("  50th Percentile (Median): %v\n", estimateTimeToMerge.P50)
 		fmt.Printf("  80th Percentile: %v\n", estimateTimeToMerge.P80)
@@ -143,26 +186,90 @@ func AnalyzePrs(prs []*github.PrData) {
 	}
 }
 
+// sampleSize describes the PRs behind an estimate, including their effective number when they are weighted.
+func sampleSize(e NormalDistributionEstimates) string {
+	if e.EffectiveSampleCount == 0 || e.EffectiveSampleCount == float64(e.SampleCount) {
+		return fmt.Sprintf("%d", e.SampleCount)
+	}
+	return fmt.Sprintf("%d (effectively %.1f)", e.SampleCount, e.EffectiveSampleCount)
+}
+
+// adjustments describes how many observations were excluded or treated as outliers, if any.
+func adjustments(e NormalDistributionEstimates) string {
+	var parts []string
+	if e.Excluded > 0 {
+		parts = append(parts, fmt.Sprintf("%d excluded", e.Excluded))
+	}
+	if e.Outliers > 0 {
+		parts = append(parts, fmt.Sprintf("%d outliers", e.Outliers))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return "; " + strings.Join(parts, ", ")
+}
+
+func durationOrNone(d time.Duration) string {
+	if d <= 0 {
+		return "none"
+	}
+	return d.String()
+}
+
 // EstimateTimesUsingNormalDistribution calculates normal distribution-based estimates
 // for a given time metric from a slice of PrMetrics.
 // It takes a selector function to pick the duration from each PrMetrics object.
 func EstimateTimesUsingNormalDistribution(metrics []*PrMetrics, selector func(*PrMetrics) time.Duration, metricName string) NormalDistributionEstimates {
-	durations := []float64{}
+	return EstimateTimesWithOptions(metrics, selector, metricName, EstimateOptions{})
+}
+
+// EstimateTimesWithOptions is EstimateTimesUsingNormalDistribution with PRs weighted by recency
+// and outliers handled as configured by opts. PRs outside the window don't count toward
+// SampleCount. With OutliersMAD, Mean and StdDev hold the median and the scaled median absolute
+// deviation, which few extreme PRs can't move.
+func EstimateTimesWithOptions(metrics []*PrMetrics, selector func(*PrMetrics) time.Duration, metricName string, opts EstimateOptions) NormalDistributionEstimates {
+	var observations []observation
+	excluded := 0
 	for _, m := range metrics {
 		val := selector(m)
-		if val > 0 { // Only include valid, non-zero durations for calculation
-			// Convert duration to hours (or any consistent unit) for stat calculations
-			durations = append(durations, val.Hours())
+		if val <= 0 { // Only include valid, non-zero durations for calculation
+			continue
 		}
+		weight := opts.Weight(m)
+		if weight <= 0 {
+			continue
+		}
+		if opts.excludes(m) {
+			excluded++
+			continue
+		}
+		// Convert duration to hours (or any consistent unit) for stat calculations
+		observations = append(observations, observation{value: val.Hours(), weight: weight})
 	}
+	observations, outliers := limitOutliers(observations, opts)
 
-	if len(durations) < 2 { // Need at least 2 data points for std dev
-		log.Printf("Warning: Not enough data points (%d) to calculate %s normal distribution. Skipping.", len(durations), metricName)
+	if len(observations) < 2 { // Need at least 2 data points for std dev
+		log.Printf("Warning: Not enough data points (%d) to calculate %s normal distribution. Skipping.", len(observations), metricName)
 		return NormalDistributionEstimates{}
 	}
 
-	mean := stat.Mean(durations, nil)
-	stdDev := stat.StdDev(durations, nil)
+	durations := make([]float64, len(observations))
+	var weights []float64
+	for i, o := range observations {
+		durations[i] = o.value
+		weights = append(weights, o.weight)
+	}
+	effective := float64(len(durations))
+	if opts.Weighted() {
+		weights, effective = effectiveWeights(weights)
+	} else {
+		weights = nil
+	}
+	mean := stat.Mean(durations, weights)
+	stdDev := stat.StdDev(durations, weights)
+	if opts.Outliers == OutliersMAD {
+		mean, stdDev, outliers = medianAndMAD(observations)
+	}
 
 	// Create a normal distribution
 	norm := distuv.Normal{
@@ -171,9 +278,12 @@ func EstimateTimesUsingNormalDistribution(metrics []*PrMetrics, selector func(*P
 	}
 
 	estimates := NormalDistributionEstimates{
-		Mean:        time.Duration(mean * float64(time.Hour)),
-		StdDev:      time.Duration(stdDev * float64(time.Hour)),
-		SampleCount: len(durations),
+		Mean:                 time.Duration(mean * float64(time.Hour)),
+		StdDev:               time.Duration(stdDev * float64(time.Hour)),
+		SampleCount:          len(durations),
+		EffectiveSampleCount: effective,
+		Excluded:             excluded,
+		Outliers:             outliers,
 	}
 
 	// Calculate percentiles using the Quantile (inverse CDF) function
@@ -1,7 +1,10 @@
 package metrics_test
 
 import (
+	"io"
 	"math"
+	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -20,6 +23,7 @@ func TestCalculateMetrics_MergedPRWithReview(t *testing.T) {
 		Additions:       100,
 		Deletions:       50,
 		ChangedFiles:    5,
+		ReviewRounds:    3,
 	}
 
 	m := metrics.CalculateMetrics(pr)
@@ -37,6 +41,9 @@ func TestCalculateMetrics_MergedPRWithReview(t *testing.T) {
 	if m.ReviewToMerge != expectedReviewToMerge {
 		t.Errorf("Expected ReviewToMerge %v, got %v", expectedReviewToMerge, m.ReviewToMerge)
 	}
+	if m.ReviewRounds != 3 {
+		t.Errorf("Expected 3 review rounds, got %d", m.ReviewRounds)
+	}
 }
 
 func TestCalculateMetrics_ClosedPRNoMerge(t *testing.T) {
@@ -148,6 +155,63 @@ func TestAnalyzePrs(t *testing.T) {
 	metrics.AnalyzePrs(prs)
 }
 
+func TestReportEstimates_IgnoresClosedUnmergedPRs(t *testing.T) {
+	now := time.Now()
+	closedAt := now.Add(-10 * time.Hour)
+	prs := []*github.PrData{
+		{Number: 1, CreatedAt: now.Add(-48 * time.Hour), MergedAt: &[]time.Time{now.Add(-24 * time.Hour)}[0], State: "closed"},
+		{Number: 2, CreatedAt: now.Add(-72 * time.Hour), MergedAt: &[]time.Time{now.Add(-24 * time.Hour)}[0], State: "closed"},
+		{Number: 3, CreatedAt: now.Add(-510 * time.Hour), ClosedAt: &closedAt, State: "closed"}, // Abandoned
+	}
+	var all []*metrics.PrMetrics
+	for _, pr := range prs {
+		all = append(all, metrics.CalculateMetrics(pr))
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	metrics.ReportEstimates(all, metrics.EstimateOptions{})
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	for _, want := range []string{"Estimated Time to Merge (based on 2 merged PRs)", "Mean: 36h0m0s"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestAnalyzePrsWithOptions(t *testing.T) {
+	now := time.Now()
+	prs := []*github.PrData{
+		{Number: 1, CreatedAt: now.Add(-48 * time.Hour), MergedAt: &[]time.Time{now.Add(-24 * time.Hour)}[0], State: "merged"},
+		{Number: 2, CreatedAt: now.Add(-72 * time.Hour), MergedAt: &[]time.Time{now.Add(-24 * time.Hour)}[0], State: "merged"},
+		{Number: 3, CreatedAt: now.Add(-500 * time.Hour), MergedAt: &[]time.Time{now.Add(-24 * time.Hour)}[0], State: "merged"},
+	}
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	metrics.AnalyzePrsWithOptions(prs, metrics.EstimateOptions{Exclude: []int{3}})
+	os.Stdout = stdout
+	w.Close()
+	out, _ := io.ReadAll(r)
+
+	for _, want := range []string{"Estimated Time to Merge (based on 2 merged PRs; 1 excluded)", "Mean: 36h0m0s"} {
+		if !strings.Contains(string(out), want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, out)
+		}
+	}
+}
+
 func TestEstimateTimesUsingNormalDistribution(t *testing.T) {
 	// Sample data in hours
 	// 24h, 48h, 36h, 60h, 30h
@@ -191,7 +255,7 @@ func TestEstimateTimesUsingNormalDistribution(t *testing.T) {
 	// For StdDev and Percentiles, check if they are within a reasonable range
 	// As exact values depend on `gonum`'s implementation, and floating point math.
 	if estimates.StdDev < 10*time.Hour || estimates.StdDev > 20*time.Hour { // Rough range
-		t.Errorf("Expected StdDev to be around 13.91h, got %v", estimates.StdDev)
+		t.Errorf("Expected StdDev to be around %.2fh, got %v", expectedStdDevHours, estimates.StdDev)
 	}
 	if estimates.P50 < 35*time.Hour || estimates.P50 > 45*time.Hour { // Roughly around the mean
 		t.Errorf("Expected P50 to be around 39.6h, got %v", estimates.P50)
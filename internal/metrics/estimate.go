@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// Size buckets group PRs by total changed lines (additions + deletions).
+const (
+	SizeXS = "XS" // < 10 lines
+	SizeS  = "S"  // < 50 lines
+	SizeM  = "M"  // < 250 lines
+	SizeL  = "L"  // < 1000 lines
+	SizeXL = "XL" // 1000+ lines
+)
+
+// MinBucketSamples is the number of historical PRs a size bucket needs before
+// estimates are based on the bucket alone instead of the whole history.
+const MinBucketSamples = 5
+
+// PrEstimate holds expected review and merge times for a single PR, derived from similar historical PRs.
+type PrEstimate struct {
+	Number            int
+	SizeBucket        string
+	BasedOn           string // "size bucket M" or "all PRs" when the bucket has too few samples
+	TimeToFirstReview NormalDistributionEstimates
+	TimeToMerge       NormalDistributionEstimates
+}
+
+// SizeBucket classifies a PR by its total number of changed lines.
+func SizeBucket(additions, deletions int) string {
+	switch lines := additions + deletions; {
+	case lines < 10:
+		return SizeXS
+	case lines < 50:
+		return SizeS
+	case lines < 250:
+		return SizeM
+	case lines < 1000:
+		return SizeL
+	default:
+		return SizeXL
+	}
+}
+
+// FirstReviewSelector picks the time to first review of a PR.
+func FirstReviewSelector(m *PrMetrics) time.Duration {
+	return m.TimeToFirstReview
+}
+
+// MergeSelector picks the time to merge of merged PRs only, so closed-unmerged PRs don't skew merge estimates.
+func MergeSelector(m *PrMetrics) time.Duration {
+	if !m.Merged {
+		return 0
+	}
+	return m.TimeToMerge
+}
+
+// EstimateForPr estimates time to first review and time to merge for pr from historical PRs
+// of the same size bucket, falling back to the whole history when the bucket is too sparse.
+func EstimateForPr(history []*PrMetrics, pr *PrMetrics) PrEstimate {
+	return EstimateForPrWithOptions(history, pr, EstimateOptions{})
+}
+
+// EstimateForPrWithOptions is EstimateForPr with historical PRs weighted by recency. Only PRs
+// inside the window count toward MinBucketSamples.
+func EstimateForPrWithOptions(history []*PrMetrics, pr *PrMetrics, opts EstimateOptions) PrEstimate {
+	estimate := PrEstimate{
+		Number:     pr.Number,
+		SizeBucket: SizeBucket(pr.Additions, pr.Deletions),
+		BasedOn:    "all PRs",
+	}
+
+	sample := history
+	var similar []*PrMetrics
+	for _, m := range history {
+		if m.Number != pr.Number && SizeBucket(m.Additions, m.Deletions) == estimate.SizeBucket {
+			similar = append(similar, m)
+		}
+	}
+	if countMerged(similar, opts) >= MinBucketSamples {
+		sample = similar
+		estimate.BasedOn = "size bucket " + estimate.SizeBucket
+	}
+
+	estimate.TimeToFirstReview = EstimateTimesWithOptions(sample, FirstReviewSelector, "Time to First Review", opts)
+	estimate.TimeToMerge = EstimateTimesWithOptions(sample, MergeSelector, "Time to Merge", opts)
+	return estimate
+}
+
+// EstimateForLabels is EstimateForPrWithOptions based on the historical PRs that share a label
+// with pr, when enough of them fall into pr's size bucket; otherwise it ignores labels.
+func EstimateForLabels(history []*PrMetrics, pr *PrMetrics, opts EstimateOptions) PrEstimate {
+	if len(pr.Labels) > 0 {
+		bucket := SizeBucket(pr.Additions, pr.Deletions)
+		var labelled, similar []*PrMetrics
+		for _, m := range history {
+			if m.Number == pr.Number || !slices.ContainsFunc(m.Labels, func(l string) bool { return slices.Contains(pr.Labels, l) }) {
+				continue
+			}
+			labelled = append(labelled, m)
+			if SizeBucket(m.Additions, m.Deletions) == bucket {
+				similar = append(similar, m)
+			}
+		}
+		if countMerged(similar, opts) >= MinBucketSamples {
+			estimate := EstimateForPrWithOptions(labelled, pr, opts)
+			estimate.BasedOn += " labelled " + strings.Join(pr.Labels, " or ")
+			return estimate
+		}
+	}
+	return EstimateForPrWithOptions(history, pr, opts)
+}
+
+func countMerged(metrics []*PrMetrics, opts EstimateOptions) int {
+	count := 0
+	for _, m := range metrics {
+		if MergeSelector(m) > 0 && opts.Weight(m) > 0 {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,84 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestSizeBucket(t *testing.T) {
+	cases := []struct {
+		additions, deletions int
+		expected             string
+	}{
+		{5, 4, metrics.SizeXS},
+		{30, 10, metrics.SizeS},
+		{200, 49, metrics.SizeM},
+		{600, 300, metrics.SizeL},
+		{1000, 0, metrics.SizeXL},
+	}
+	for _, c := range cases {
+		if got := metrics.SizeBucket(c.additions, c.deletions); got != c.expected {
+			t.Errorf("SizeBucket(%d, %d) = %s, expected %s", c.additions, c.deletions, got, c.expected)
+		}
+	}
+}
+
+func TestEstimateForPr_UsesSizeBucket(t *testing.T) {
+	var history []*metrics.PrMetrics
+	// Five small PRs merged within a few hours and five large PRs taking days
+	for i := 0; i < 5; i++ {
+		history = append(history,
+			&metrics.PrMetrics{Number: i, Additions: 20, Merged: true, TimeToMerge: time.Duration(2+i) * time.Hour, TimeToFirstReview: time.Hour},
+			&metrics.PrMetrics{Number: 100 + i, Additions: 2000, Merged: true, TimeToMerge: time.Duration(48+i) * time.Hour, TimeToFirstReview: 10 * time.Hour},
+		)
+	}
+
+	estimate := metrics.EstimateForPr(history, &metrics.PrMetrics{Number: 999, Additions: 15, Deletions: 5})
+	if estimate.SizeBucket != metrics.SizeS || estimate.BasedOn != "size bucket S" {
+		t.Errorf("Expected estimate based on size bucket S, got %q (%s)", estimate.BasedOn, estimate.SizeBucket)
+	}
+	if estimate.TimeToMerge.SampleCount != 5 || estimate.TimeToMerge.Mean != 4*time.Hour {
+		t.Errorf("Expected merge estimate from the 5 small PRs (mean 4h), got %+v", estimate.TimeToMerge)
+	}
+}
+
+func TestEstimateForPr_FallsBackToAllPRs(t *testing.T) {
+	history := []*metrics.PrMetrics{
+		{Number: 1, Additions: 20, Merged: true, TimeToMerge: 2 * time.Hour},
+		{Number: 2, Additions: 2000, Merged: true, TimeToMerge: 50 * time.Hour},
+		{Number: 3, Additions: 2000, Merged: false, TimeToMerge: 500 * time.Hour}, // Closed unmerged, ignored
+	}
+
+	estimate := metrics.EstimateForPr(history, &metrics.PrMetrics{Number: 4, Additions: 30})
+	if estimate.BasedOn != "all PRs" {
+		t.Errorf("Expected fallback to all PRs, got %q", estimate.BasedOn)
+	}
+	if estimate.TimeToMerge.SampleCount != 2 || estimate.TimeToMerge.Mean != 26*time.Hour {
+		t.Errorf("Expected merge estimate from the 2 merged PRs (mean 26h), got %+v", estimate.TimeToMerge)
+	}
+}
+
+func TestEstimateForLabels(t *testing.T) {
+	var history []*metrics.PrMetrics
+	for i := 0; i < 5; i++ {
+		// Docs PRs merge within hours, backend PRs of the same size take days
+		history = append(history,
+			&metrics.PrMetrics{Number: i, Additions: 20, Labels: []string{"docs"}, Merged: true, TimeToMerge: time.Duration(2+i) * time.Hour},
+			&metrics.PrMetrics{Number: 100 + i, Additions: 20, Labels: []string{"backend"}, Merged: true, TimeToMerge: time.Duration(48+i) * time.Hour},
+		)
+	}
+	history = append(history, &metrics.PrMetrics{Number: 200, Additions: 20, Labels: []string{"security"}, Merged: true, TimeToMerge: 100 * time.Hour})
+
+	estimate := metrics.EstimateForLabels(history, &metrics.PrMetrics{Number: 999, Additions: 20, Labels: []string{"docs"}}, metrics.EstimateOptions{})
+	if estimate.BasedOn != "size bucket S labelled docs" || estimate.TimeToMerge.Mean != 4*time.Hour {
+		t.Errorf("Expected an estimate from the docs PRs (mean 4h), got %q with %+v", estimate.BasedOn, estimate.TimeToMerge)
+	}
+
+	// One security PR is too few, so the label is ignored
+	estimate = metrics.EstimateForLabels(history, &metrics.PrMetrics{Number: 999, Additions: 20, Labels: []string{"security"}}, metrics.EstimateOptions{})
+	if estimate.BasedOn != "size bucket S" || estimate.TimeToMerge.SampleCount != 11 {
+		t.Errorf("Expected an estimate from all 11 S PRs, got %q from %d PRs", estimate.BasedOn, estimate.TimeToMerge.SampleCount)
+	}
+}
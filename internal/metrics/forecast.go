@@ -0,0 +1,166 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// DefaultForecastTrials is the number of simulated futures when ForecastOptions.Trials is zero.
+const DefaultForecastTrials = 10000
+
+// ForecastItem is a PR to forecast: an open PR, or a hypothetical one of a given size that
+// starts now.
+type ForecastItem struct {
+	Number     int    // 0 for hypothetical PRs
+	Title      string // Optional
+	SizeBucket string
+	CreatedAt  time.Time // Zero for hypothetical PRs
+}
+
+// ForecastOptions tunes MonteCarloForecast.
+type ForecastOptions struct {
+	Trials int
+	Seed   uint64 // Same seed, same forecast
+	Now    time.Time
+}
+
+// ItemForecast is the forecast merge date of a single PR.
+type ItemForecast struct {
+	ForecastItem
+	BasedOn string // "size bucket M" or "all PRs"
+	P50     time.Time
+	P90     time.Time
+}
+
+// ForecastDay is the probability that the whole batch is merged by the end of Date.
+type ForecastDay struct {
+	Date        time.Time
+	Probability float64
+}
+
+// Forecast is the distribution of the date by which a batch of PRs will all be merged.
+type Forecast struct {
+	Trials             int
+	Items              []ItemForecast
+	P50, P80, P90, P95 time.Time     // Dates by which the whole batch is merged with that probability
+	ByDay              []ForecastDay // Cumulative, from the first day any trial completes to the P95 day
+}
+
+// logNormalFit is a log-normal distribution of merge times in hours.
+type logNormalFit struct {
+	dist    distuv.LogNormal
+	basedOn string
+}
+
+// MonteCarloForecast simulates when all items will be merged by drawing each PR's time to merge
+// from a log-normal distribution fitted to merged PRs of its size bucket, falling back to all
+// merged PRs when the bucket has fewer than MinBucketSamples. Open PRs are drawn conditionally
+// on not being merged yet at their current age.
+func MonteCarloForecast(history []*PrMetrics, items []ForecastItem, opts ForecastOptions) (Forecast, error) {
+	if len(items) == 0 {
+		return Forecast{}, fmt.Errorf("no pull requests to forecast")
+	}
+	trials := opts.Trials
+	if trials <= 0 {
+		trials = DefaultForecastTrials
+	}
+
+	all, ok := fitLogNormal(history, "all PRs")
+	if !ok {
+		return Forecast{}, fmt.Errorf("need at least %d merged PRs with different merge times to fit a distribution", MinBucketSamples)
+	}
+	buckets := make(map[string][]*PrMetrics)
+	for _, m := range history {
+		bucket := SizeBucket(m.Additions, m.Deletions)
+		buckets[bucket] = append(buckets[bucket], m)
+	}
+	fits := make([]logNormalFit, len(items))
+	for i, item := range items {
+		fits[i] = all
+		if fit, ok := fitLogNormal(buckets[item.SizeBucket], "size bucket "+item.SizeBucket); ok {
+			fits[i] = fit
+		}
+	}
+
+	rng := rand.New(rand.NewPCG(opts.Seed, opts.Seed^0x9e3779b97f4a7c15))
+	batch := make([]float64, trials)         // Hours from now until the batch is merged
+	perItem := make([][]float64, len(items)) // Hours from now until each PR is merged
+	for i := range perItem {
+		perItem[i] = make([]float64, trials)
+	}
+	for t := 0; t < trials; t++ {
+		for i, item := range items {
+			age := 0.0
+			if !item.CreatedAt.IsZero() {
+				age = math.Max(opts.Now.Sub(item.CreatedAt).Hours(), 0)
+			}
+			// Inverse transform sampling from the distribution truncated below at the current age
+			lower := fits[i].dist.CDF(age)
+			u := math.Min(lower+(1-lower)*rng.Float64(), 1-1e-12)
+			remaining := math.Max(fits[i].dist.Quantile(u)-age, 0)
+			perItem[i][t] = remaining
+			batch[t] = math.Max(batch[t], remaining)
+		}
+	}
+
+	at := func(sorted []float64, q float64) time.Time {
+		return opts.Now.Add(time.Duration(stat.Quantile(q, stat.Empirical, sorted, nil) * float64(time.Hour))).Round(time.Minute)
+	}
+	sort.Float64s(batch)
+	forecast := Forecast{
+		Trials: trials,
+		P50:    at(batch, 0.5),
+		P80:    at(batch, 0.8),
+		P90:    at(batch, 0.9),
+		P95:    at(batch, 0.95),
+	}
+	for i, item := range items {
+		sort.Float64s(perItem[i])
+		forecast.Items = append(forecast.Items, ItemForecast{
+			ForecastItem: item,
+			BasedOn:      fits[i].basedOn,
+			P50:          at(perItem[i], 0.5),
+			P90:          at(perItem[i], 0.9),
+		})
+	}
+
+	day := endOfDay(opts.Now.Add(time.Duration(batch[0] * float64(time.Hour))))
+	for ; !day.After(endOfDay(forecast.P95)); day = endOfDay(day.Add(time.Hour)) {
+		hours := day.Sub(opts.Now).Hours()
+		done := sort.Search(len(batch), func(i int) bool { return batch[i] > hours })
+		forecast.ByDay = append(forecast.ByDay, ForecastDay{
+			Date:        time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location()),
+			Probability: float64(done) / float64(trials),
+		})
+	}
+	return forecast, nil
+}
+
+// fitLogNormal fits a log-normal distribution to the merge times of merged PRs in hours.
+func fitLogNormal(history []*PrMetrics, basedOn string) (logNormalFit, bool) {
+	var logs []float64
+	for _, m := range history {
+		if d := MergeSelector(m); d > 0 {
+			logs = append(logs, math.Log(d.Hours()))
+		}
+	}
+	if len(logs) < MinBucketSamples {
+		return logNormalFit{}, false
+	}
+	mu, sigma := stat.MeanStdDev(logs, nil)
+	if sigma == 0 || math.IsNaN(sigma) {
+		return logNormalFit{}, false
+	}
+	return logNormalFit{dist: distuv.LogNormal{Mu: mu, Sigma: sigma}, basedOn: basedOn}, true
+}
+
+// endOfDay returns the last nanosecond of t's day.
+func endOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location()).AddDate(0, 0, 1).Add(-time.Nanosecond)
+}
@@ -0,0 +1,66 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestMonteCarloForecast(t *testing.T) {
+	now := time.Date(2025, 6, 2, 9, 0, 0, 0, time.UTC)
+	var history []*metrics.PrMetrics
+	// Small PRs merge within hours, large ones take days
+	for i, hours := range []float64{2, 3, 4, 5, 6, 8} {
+		history = append(history, &metrics.PrMetrics{Number: i + 1, Additions: 20, Merged: true, TimeToMerge: time.Duration(hours * float64(time.Hour))})
+	}
+	for i, days := range []float64{2, 3, 4, 5, 7} {
+		history = append(history, &metrics.PrMetrics{Number: i + 10, Additions: 600, Merged: true, TimeToMerge: time.Duration(days * 24 * float64(time.Hour))})
+	}
+
+	opts := metrics.ForecastOptions{Trials: 5000, Seed: 1, Now: now}
+	small, err := metrics.MonteCarloForecast(history, []metrics.ForecastItem{{SizeBucket: metrics.SizeS}}, opts)
+	if err != nil {
+		t.Fatalf("MonteCarloForecast failed: %v", err)
+	}
+	if small.Items[0].BasedOn != "size bucket S" || small.P50.Sub(now) > 12*time.Hour {
+		t.Errorf("Expected a small PR to merge within hours, got P50 %v (%s)", small.P50, small.Items[0].BasedOn)
+	}
+
+	items := []metrics.ForecastItem{
+		{SizeBucket: metrics.SizeS},
+		{Number: 42, SizeBucket: metrics.SizeL, CreatedAt: now.Add(-6 * 24 * time.Hour)},
+		{SizeBucket: metrics.SizeXS}, // Too few samples, uses all PRs
+	}
+	batch, err := metrics.MonteCarloForecast(history, items, opts)
+	if err != nil {
+		t.Fatalf("MonteCarloForecast failed: %v", err)
+	}
+	if batch.Items[2].BasedOn != "all PRs" {
+		t.Errorf("Expected the XS PR to fall back to all PRs, got %s", batch.Items[2].BasedOn)
+	}
+	if !batch.P50.Before(batch.P80) || !batch.P80.Before(batch.P90) || !batch.P90.Before(batch.P95) {
+		t.Errorf("Expected increasing percentile dates, got %v %v %v %v", batch.P50, batch.P80, batch.P90, batch.P95)
+	}
+	if !batch.P50.After(small.P50) {
+		t.Errorf("Expected the batch to take longer than its smallest PR, got %v vs %v", batch.P50, small.P50)
+	}
+	// The L PR is already 6 days old, so it can't take its full unconditional time from now
+	if old := batch.Items[1]; old.P50.After(now.Add(6*24*time.Hour)) || !old.P50.After(now) {
+		t.Errorf("Expected the old PR to merge within days, got %v", old.P50)
+	}
+
+	last := batch.ByDay[len(batch.ByDay)-1]
+	if last.Probability < 0.95 || batch.ByDay[0].Probability > last.Probability {
+		t.Errorf("Expected a cumulative distribution reaching 95%%, got %+v", batch.ByDay)
+	}
+
+	again, _ := metrics.MonteCarloForecast(history, items, opts)
+	if !again.P90.Equal(batch.P90) {
+		t.Errorf("Expected the same seed to give the same forecast, got %v and %v", again.P90, batch.P90)
+	}
+
+	if _, err := metrics.MonteCarloForecast(history[:3], items, opts); err == nil {
+		t.Error("Expected an error with too little history")
+	}
+}
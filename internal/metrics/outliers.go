@@ -0,0 +1,136 @@
+package metrics
+
+import (
+	"math"
+	"sort"
+)
+
+// Outlier handling methods of EstimateOptions.
+const (
+	OutliersTrim      = "trim"      // Drop observations outside the quantiles
+	OutliersWinsorize = "winsorize" // Clamp observations outside the quantiles to them
+	OutliersMAD       = "mad"       // Use the median and the scaled median absolute deviation instead of mean and standard deviation
+)
+
+// Default quantiles observations are trimmed or winsorized at.
+const (
+	DefaultLowerQuantile = 0.05
+	DefaultUpperQuantile = 0.95
+)
+
+// madScale turns the median absolute deviation into a consistent estimate of the standard
+// deviation of normally distributed data.
+const madScale = 1.4826
+
+// madOutlier is the number of scaled MADs from the median beyond which an observation counts as
+// an outlier when reporting.
+const madOutlier = 3
+
+// quantiles returns the quantiles to trim or winsorize at, falling back to the defaults.
+func (o EstimateOptions) quantiles() (float64, float64) {
+	if o.LowerQuantile == 0 && o.UpperQuantile == 0 {
+		return DefaultLowerQuantile, DefaultUpperQuantile
+	}
+	return o.LowerQuantile, o.UpperQuantile
+}
+
+// excludes reports whether a PR is listed in Exclude.
+func (o EstimateOptions) excludes(m *PrMetrics) bool {
+	for _, number := range o.Exclude {
+		if m.Number == number {
+			return true
+		}
+	}
+	return false
+}
+
+// observation is a duration in hours with its weight.
+type observation struct {
+	value, weight float64
+}
+
+// limitOutliers applies the trim or winsorize method of opts to observations. An observation
+// is an outlier when all of its weight lies below the lower or above the upper quantile, so
+// with equal weights and n observations floor(q·n) are affected at each end. It returns the
+// remaining observations and the number affected.
+func limitOutliers(observations []observation, opts EstimateOptions) ([]observation, int) {
+	if opts.Outliers != OutliersTrim && opts.Outliers != OutliersWinsorize {
+		return observations, 0
+	}
+	sorted := sortedObservations(observations)
+	lower, upper := opts.quantiles()
+	var total float64
+	for _, o := range sorted {
+		total += o.weight
+	}
+	const epsilon = 1e-9
+
+	first, last := 0, len(sorted)-1
+	var cumulative float64
+	for i, o := range sorted {
+		before := cumulative / total
+		cumulative += o.weight
+		if cumulative/total <= lower+epsilon {
+			first = i + 1
+		}
+		if before >= upper-epsilon && last == len(sorted)-1 {
+			last = i - 1
+		}
+	}
+	if first > last {
+		return observations, 0
+	}
+	affected := first + len(sorted) - 1 - last
+	if opts.Outliers == OutliersTrim {
+		return sorted[first : last+1], affected
+	}
+	for i := range sorted {
+		sorted[i].value = min(max(sorted[i].value, sorted[first].value), sorted[last].value)
+	}
+	return sorted, affected
+}
+
+// medianAndMAD returns the weighted median of observations, the scaled median absolute
+// deviation around it, and how many observations lie more than madOutlier scaled MADs away.
+func medianAndMAD(observations []observation) (float64, float64, int) {
+	median := weightedMedian(observations)
+	deviations := make([]observation, len(observations))
+	for i, o := range observations {
+		deviations[i] = observation{value: math.Abs(o.value - median), weight: o.weight}
+	}
+	sigma := madScale * weightedMedian(deviations)
+	outliers := 0
+	for _, d := range deviations {
+		if d.value > madOutlier*sigma {
+			outliers++
+		}
+	}
+	return median, sigma, outliers
+}
+
+// weightedMedian returns the smallest value at which the cumulative weight reaches half the total.
+func weightedMedian(observations []observation) float64 {
+	sorted := sortedObservations(observations)
+	var total float64
+	for _, o := range sorted {
+		total += o.weight
+	}
+	var cumulative float64
+	for i, o := range sorted {
+		cumulative += o.weight
+		if cumulative >= total/2 {
+			// With equal weights and an even count, average the two middle values
+			if cumulative == total/2 && i+1 < len(sorted) {
+				return (o.value + sorted[i+1].value) / 2
+			}
+			return o.value
+		}
+	}
+	return math.NaN()
+}
+
+func sortedObservations(observations []observation) []observation {
+	sorted := append([]observation(nil), observations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].value < sorted[j].value })
+	return sorted
+}
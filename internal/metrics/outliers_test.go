@@ -0,0 +1,56 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// outlierHistory returns 19 PRs merged in 10 to 28 hours and one left open for 3 months.
+func outlierHistory() []*metrics.PrMetrics {
+	var history []*metrics.PrMetrics
+	for i := 0; i < 19; i++ {
+		history = append(history, &metrics.PrMetrics{Number: i + 1, Merged: true, TimeToMerge: time.Duration(10+i) * time.Hour})
+	}
+	return append(history, &metrics.PrMetrics{Number: 20, Merged: true, TimeToMerge: 90 * 24 * time.Hour})
+}
+
+func TestEstimateTimesWithOptions_Outliers(t *testing.T) {
+	history := outlierHistory()
+	plain := metrics.EstimateTimesUsingNormalDistribution(history, metrics.MergeSelector, "Time to Merge")
+	if plain.Mean < 100*time.Hour || plain.Outliers != 0 {
+		t.Fatalf("Expected the 3 month PR to dominate the plain mean, got %+v", plain)
+	}
+
+	trimmed := metrics.EstimateTimesWithOptions(history, metrics.MergeSelector, "Time to Merge",
+		metrics.EstimateOptions{Outliers: metrics.OutliersTrim, LowerQuantile: 0.1, UpperQuantile: 0.9})
+	// 10% of 20 is two PRs at each end, leaving 12h to 27h
+	if trimmed.SampleCount != 16 || trimmed.Outliers != 4 || trimmed.Mean != 19*time.Hour+30*time.Minute {
+		t.Errorf("Expected 4 trimmed PRs and a mean of 19h30m, got %+v", trimmed)
+	}
+
+	winsorized := metrics.EstimateTimesWithOptions(history, metrics.MergeSelector, "Time to Merge",
+		metrics.EstimateOptions{Outliers: metrics.OutliersWinsorize})
+	// The defaults affect one PR at each end: 10h becomes 11h and 3 months become 28h
+	if winsorized.SampleCount != 20 || winsorized.Outliers != 2 || winsorized.Mean != 19*time.Hour+30*time.Minute {
+		t.Errorf("Expected 2 winsorized PRs and a mean of 19h30m, got %+v", winsorized)
+	}
+
+	robust := metrics.EstimateTimesWithOptions(history, metrics.MergeSelector, "Time to Merge",
+		metrics.EstimateOptions{Outliers: metrics.OutliersMAD})
+	if robust.Mean != 19*time.Hour+30*time.Minute || robust.P50 != robust.Mean || robust.Outliers != 1 {
+		t.Errorf("Expected the median 19h30m and one outlier, got %+v", robust)
+	}
+	if robust.StdDev < 7*time.Hour || robust.StdDev > 8*time.Hour {
+		t.Errorf("Expected a scaled MAD of about 7.4h, got %v", robust.StdDev)
+	}
+}
+
+func TestEstimateTimesWithOptions_Exclude(t *testing.T) {
+	estimate := metrics.EstimateTimesWithOptions(outlierHistory(), metrics.MergeSelector, "Time to Merge",
+		metrics.EstimateOptions{Exclude: []int{20, 404}})
+	if estimate.SampleCount != 19 || estimate.Excluded != 1 || estimate.Mean != 19*time.Hour {
+		t.Errorf("Expected PR #20 to be excluded, got %+v", estimate)
+	}
+}
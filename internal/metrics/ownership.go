@@ -0,0 +1,81 @@
+package metrics
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// GroupLatency summarizes the review latency of PRs touching one area, such as an owning team
+// or a top-level directory.
+type GroupLatency struct {
+	Group             string
+	Prs               int
+	Open              int
+	TimeToFirstReview DurationSummary
+	TimeToMerge       DurationSummary // Merged PRs only
+}
+
+// LatencyByGroup summarizes review latency per group, where groups lists the groups of a PR.
+// PRs in several groups count toward each of them. Groups are returned slowest to first review
+// first, so bottlenecks lead.
+func LatencyByGroup(prs []*github.PrData, groups func(*github.PrData) []string) []GroupLatency {
+	byGroup := make(map[string]*GroupLatency)
+	firstReviews := make(map[string][]time.Duration)
+	merges := make(map[string][]time.Duration)
+	for _, pr := range prs {
+		m := CalculateMetrics(pr)
+		for _, group := range groups(pr) {
+			g := byGroup[group]
+			if g == nil {
+				g = &GroupLatency{Group: group}
+				byGroup[group] = g
+			}
+			g.Prs++
+			if pr.State == "open" {
+				g.Open++
+			}
+			if d := FirstReviewSelector(m); d > 0 {
+				firstReviews[group] = append(firstReviews[group], d)
+			}
+			if d := MergeSelector(m); d > 0 {
+				merges[group] = append(merges[group], d)
+			}
+		}
+	}
+
+	result := make([]GroupLatency, 0, len(byGroup))
+	for group, g := range byGroup {
+		g.TimeToFirstReview = Summarize(firstReviews[group])
+		g.TimeToMerge = Summarize(merges[group])
+		result = append(result, *g)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].TimeToFirstReview.P50 != result[j].TimeToFirstReview.P50 {
+			return result[i].TimeToFirstReview.P50 > result[j].TimeToFirstReview.P50
+		}
+		return result[i].Group < result[j].Group
+	})
+	return result
+}
+
+// TopLevelDirectories returns the sorted top-level directories of the files a PR changed, with
+// "/" standing for files at the repository root. PRs without file data have none.
+func TopLevelDirectories(pr *github.PrData) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, f := range pr.Files {
+		dir, _, nested := strings.Cut(strings.TrimPrefix(f.Path, "/"), "/")
+		if !nested {
+			dir = "/"
+		}
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	sort.Strings(dirs)
+	return dirs
+}
@@ -0,0 +1,57 @@
+package metrics_test
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestLatencyByGroup(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	pr := func(number int, reviewAfter, mergeAfter time.Duration, paths ...string) *github.PrData {
+		p := &github.PrData{Number: number, State: "closed", CreatedAt: start}
+		if reviewAfter > 0 {
+			reviewed := start.Add(reviewAfter)
+			p.FirstReviewedAt = &reviewed
+		}
+		if mergeAfter > 0 {
+			merged := start.Add(mergeAfter)
+			p.MergedAt = &merged
+		} else {
+			p.State = "open"
+		}
+		for _, path := range paths {
+			p.Files = append(p.Files, github.FileChange{Path: path})
+		}
+		return p
+	}
+	prs := []*github.PrData{
+		pr(1, 2*time.Hour, 10*time.Hour, "api/github/client.go", "README.md"),
+		pr(2, 4*time.Hour, 20*time.Hour, "api/gitlab/client.go"),
+		pr(3, 30*time.Hour, 50*time.Hour, "internal/metrics/trend.go", "api/api.go"),
+		pr(4, 0, 0, "internal/report/html.go"),
+		pr(5, time.Hour, 2*time.Hour), // No file data
+	}
+
+	if got := metrics.TopLevelDirectories(prs[0]); !slices.Equal(got, []string{"/", "api"}) {
+		t.Errorf("Expected [/ api], got %v", got)
+	}
+
+	groups := metrics.LatencyByGroup(prs, metrics.TopLevelDirectories)
+	if len(groups) != 3 {
+		t.Fatalf("Expected internal, api and /, got %+v", groups)
+	}
+	internal, api, root := groups[0], groups[1], groups[2]
+	if internal.Group != "internal" || internal.Prs != 2 || internal.Open != 1 || internal.TimeToFirstReview.Count != 1 || internal.TimeToFirstReview.P50 != 30*time.Hour {
+		t.Errorf("Unexpected latency for internal: %+v", internal)
+	}
+	if api.Group != "api" || api.Prs != 3 || api.TimeToFirstReview.P50 != 4*time.Hour || api.TimeToMerge.P50 != 20*time.Hour {
+		t.Errorf("Unexpected latency for api: %+v", api)
+	}
+	if root.Group != "/" || root.Prs != 1 {
+		t.Errorf("Unexpected latency for /: %+v", root)
+	}
+}
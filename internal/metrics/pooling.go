@@ -0,0 +1,142 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"gonum.org/v1/gonum/stat"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// PosteriorLevel is the probability covered by the posterior intervals of PooledEstimate.
+const PosteriorLevel = 0.9
+
+// GroupEstimate is the partially pooled estimate of a duration for one group of PRs, e.g. an
+// author or a label.
+type GroupEstimate struct {
+	Group       string
+	SampleCount int
+	RawMedian   time.Duration // The group's own sample median
+	// Median is the posterior estimate of the group's typical duration, shrunk toward the
+	// repository-wide median the more the fewer samples the group has.
+	Median     time.Duration
+	MedianLow  time.Duration // PosteriorLevel interval of Median
+	MedianHigh time.Duration
+	P90        time.Duration // 90th percentile predicted for the next PR of the group
+	Shrinkage  float64       // Weight of the repository-wide median in Median, from 0 (none) to 1 (fully pooled)
+}
+
+// PooledEstimate holds estimates for all groups together with the repository-wide
+// distribution they are shrunk toward.
+type PooledEstimate struct {
+	SampleCount   int
+	RepoMedian    time.Duration
+	WithinStdDev  float64 // Spread of log durations within groups
+	BetweenStdDev float64 // Spread of the groups' typical log durations; 0 means groups don't differ
+	Groups        []GroupEstimate
+}
+
+// ByAuthor groups PRs by their author.
+func ByAuthor(m *PrMetrics) []string {
+	return []string{m.Author}
+}
+
+// ByLabel groups PRs by their labels; PRs with several labels count toward each.
+func ByLabel(m *PrMetrics) []string {
+	return m.Labels
+}
+
+// PooledGroupEstimates estimates the duration picked by selector per group with an empirical
+// Bayes normal hierarchical model on log durations: each group's mean log duration is drawn
+// from a repository-wide normal distribution whose spread is estimated by the method of
+// moments. Sparse groups are shrunk toward the repository-wide mean, while groups with many
+// samples keep close to their own. Hyperparameter uncertainty is ignored, so intervals are
+// slightly too narrow when there are few groups.
+func PooledGroupEstimates(history []*PrMetrics, selector func(*PrMetrics) time.Duration, groupBy func(*PrMetrics) []string) (PooledEstimate, error) {
+	var all []float64
+	samples := make(map[string][]float64)
+	for _, m := range history {
+		d := selector(m)
+		if d <= 0 {
+			continue
+		}
+		y := math.Log(d.Hours())
+		all = append(all, y)
+		for _, group := range groupBy(m) {
+			samples[group] = append(samples[group], y)
+		}
+	}
+	if len(all) < 2 {
+		return PooledEstimate{}, fmt.Errorf("need at least 2 PRs to pool estimates, got %d", len(all))
+	}
+
+	// Within-group variance pooled over all groups, falling back to the overall variance
+	// when no group has more than one sample
+	mu, overall := stat.MeanVariance(all, nil)
+	var squares float64
+	var dof int
+	for _, ys := range samples {
+		mean := stat.Mean(ys, nil)
+		for _, y := range ys {
+			squares += (y - mean) * (y - mean)
+		}
+		dof += len(ys) - 1
+	}
+	within := overall
+	if dof > 0 && squares > 0 {
+		within = squares / float64(dof)
+	}
+
+	// Between-group variance: the spread of group means beyond what sampling noise explains
+	var between float64
+	if len(samples) > 1 {
+		var means, noise []float64
+		for _, ys := range samples {
+			means = append(means, stat.Mean(ys, nil))
+			noise = append(noise, within/float64(len(ys)))
+		}
+		between = math.Max(stat.Variance(means, nil)-stat.Mean(noise, nil), 0)
+	}
+
+	z := distuv.UnitNormal.Quantile(0.5 + PosteriorLevel/2)
+	hours := func(logHours float64) time.Duration {
+		return time.Duration(math.Exp(logHours) * float64(time.Hour))
+	}
+	estimate := PooledEstimate{
+		SampleCount:   len(all),
+		RepoMedian:    hours(mu),
+		WithinStdDev:  math.Sqrt(within),
+		BetweenStdDev: math.Sqrt(between),
+	}
+	for group, ys := range samples {
+		n := float64(len(ys))
+		// Shrinkage is the share of the group mean's sampling variance in its total variance
+		shrinkage, variance := 1.0, within/float64(len(all))
+		if between > 0 {
+			shrinkage = (within / n) / (within/n + between)
+			variance = 1 / (n/within + 1/between)
+		}
+		theta := shrinkage*mu + (1-shrinkage)*stat.Mean(ys, nil)
+		predictive := distuv.LogNormal{Mu: theta, Sigma: math.Sqrt(within + variance)}
+
+		estimate.Groups = append(estimate.Groups, GroupEstimate{
+			Group:       group,
+			SampleCount: len(ys),
+			RawMedian:   hours(median(ys)),
+			Median:      hours(theta),
+			MedianLow:   hours(theta - z*math.Sqrt(variance)),
+			MedianHigh:  hours(theta + z*math.Sqrt(variance)),
+			P90:         time.Duration(predictive.Quantile(0.9) * float64(time.Hour)),
+			Shrinkage:   shrinkage,
+		})
+	}
+	sort.Slice(estimate.Groups, func(i, j int) bool {
+		if estimate.Groups[i].Median != estimate.Groups[j].Median {
+			return estimate.Groups[i].Median > estimate.Groups[j].Median
+		}
+		return estimate.Groups[i].Group < estimate.Groups[j].Group
+	})
+	return estimate, nil
+}
@@ -0,0 +1,79 @@
+package metrics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestPooledGroupEstimates(t *testing.T) {
+	var history []*metrics.PrMetrics
+	add := func(author string, hours ...float64) {
+		for _, h := range hours {
+			history = append(history, &metrics.PrMetrics{
+				Number: len(history) + 1, Author: author, Labels: []string{"team-" + author},
+				TimeToFirstReview: time.Duration(h * float64(time.Hour)),
+			})
+		}
+	}
+	// alice and bob have plenty of history; carol has two slow PRs
+	add("alice", 6, 8, 10, 12, 14, 9, 11, 7, 13, 10, 8, 12)
+	add("bob", 30, 40, 50, 35, 45, 38, 42, 36, 44, 40, 33, 47)
+	add("carol", 150, 250)
+
+	pooled, err := metrics.PooledGroupEstimates(history, metrics.FirstReviewSelector, metrics.ByAuthor)
+	if err != nil {
+		t.Fatalf("PooledGroupEstimates failed: %v", err)
+	}
+	if pooled.SampleCount != 26 || pooled.BetweenStdDev == 0 || len(pooled.Groups) != 3 {
+		t.Fatalf("Unexpected pooled estimate %+v", pooled)
+	}
+
+	byGroup := make(map[string]metrics.GroupEstimate)
+	for _, g := range pooled.Groups {
+		byGroup[g.Group] = g
+		if g.MedianLow > g.Median || g.Median > g.MedianHigh || g.P90 < g.Median {
+			t.Errorf("Expected %s's median inside its interval and below its P90, got %+v", g.Group, g)
+		}
+	}
+	alice, carol := byGroup["alice"], byGroup["carol"]
+	if carol.Shrinkage <= alice.Shrinkage {
+		t.Errorf("Expected the sparse group to be shrunk more, got %.2f vs %.2f", carol.Shrinkage, alice.Shrinkage)
+	}
+	if carol.Median >= carol.RawMedian || carol.Median <= pooled.RepoMedian {
+		t.Errorf("Expected carol's %v median between the repo's %v and her own %v", carol.Median, pooled.RepoMedian, carol.RawMedian)
+	}
+	if math.Abs(alice.Median.Hours()-alice.RawMedian.Hours()) > 1 {
+		t.Errorf("Expected alice's estimate to stay near her own median %v, got %v", alice.RawMedian, alice.Median)
+	}
+	if carol.MedianHigh-carol.MedianLow <= alice.MedianHigh-alice.MedianLow {
+		t.Error("Expected a wider interval for the sparse group")
+	}
+
+	byLabel, err := metrics.PooledGroupEstimates(history, metrics.FirstReviewSelector, metrics.ByLabel)
+	if err != nil || byLabel.Groups[0].Group != "team-carol" {
+		t.Errorf("Expected the slowest label first, got %+v (err %v)", byLabel.Groups, err)
+	}
+}
+
+func TestPooledGroupEstimates_SimilarGroupsPoolFully(t *testing.T) {
+	var history []*metrics.PrMetrics
+	for i, h := range []float64{10, 20, 10, 20, 10, 20} {
+		history = append(history, &metrics.PrMetrics{Author: []string{"alice", "bob", "carol"}[i%3], TimeToFirstReview: time.Duration(h * float64(time.Hour))})
+	}
+	pooled, err := metrics.PooledGroupEstimates(history, metrics.FirstReviewSelector, metrics.ByAuthor)
+	if err != nil {
+		t.Fatalf("PooledGroupEstimates failed: %v", err)
+	}
+	for _, g := range pooled.Groups {
+		if g.Shrinkage != 1 || g.Median.Round(time.Second) != pooled.RepoMedian.Round(time.Second) {
+			t.Errorf("Expected %s to be fully pooled, got %+v", g.Group, g)
+		}
+	}
+
+	if _, err := metrics.PooledGroupEstimates(history[:1], metrics.FirstReviewSelector, metrics.ByAuthor); err == nil {
+		t.Error("Expected an error with a single PR")
+	}
+}
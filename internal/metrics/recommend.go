@@ -0,0 +1,97 @@
+package metrics
+
+import (
+	"path"
+	"sort"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// RecommendOptions tunes RecommendReviewers.
+type RecommendOptions struct {
+	// MinResponses is the number of past responses a reviewer needs to be recommended.
+	MinResponses int
+	// QueuePenalty is the relative slowdown per review request already waiting for the reviewer.
+	QueuePenalty float64
+	// FamiliarityWeight is how much of the expected time full familiarity with the changed files
+	// takes off the ranking score, between 0 and 1.
+	FamiliarityWeight float64
+}
+
+// DefaultRecommendOptions assume each queued request delays a reviewer by a quarter of their
+// usual response time and let familiarity halve the ranking score at most.
+var DefaultRecommendOptions = RecommendOptions{MinResponses: 3, QueuePenalty: 0.25, FamiliarityWeight: 0.5}
+
+// ReviewerRecommendation is a candidate reviewer for a PR.
+type ReviewerRecommendation struct {
+	Reviewer string
+	// ExpectedFirstReview is the reviewer's median response time, stretched by their open requests.
+	ExpectedFirstReview time.Duration
+	MedianResponse      time.Duration
+	P90Response         time.Duration
+	Responses           int
+	OpenRequests        int
+	// Familiarity is the share of the PR's files the reviewer reviewed before, with files
+	// in directories they reviewed counting half.
+	Familiarity float64
+	Score       float64 // Ranking score in hours; lower is better
+}
+
+// RecommendReviewers ranks reviewers for a PR by author touching files, from their historical
+// response times, their current queue of open review requests and their familiarity with the
+// files. The author and teams are never recommended.
+func RecommendReviewers(history []*github.PrData, author string, files []string, opts RecommendOptions, now time.Time) []ReviewerRecommendation {
+	a := collectReviewerActivity(history, now)
+
+	var recommendations []ReviewerRecommendation
+	for reviewer, s := range a.stats {
+		responses := a.responses[reviewer]
+		if s.Team || reviewer == author || len(responses) < max(opts.MinResponses, 1) {
+			continue
+		}
+		summary := Summarize(responses)
+
+		r := ReviewerRecommendation{
+			Reviewer:       reviewer,
+			MedianResponse: summary.P50,
+			P90Response:    summary.P90,
+			Responses:      summary.Count,
+			OpenRequests:   s.OpenRequests,
+			Familiarity:    familiarity(a.files[reviewer], files),
+		}
+		r.ExpectedFirstReview = time.Duration(float64(r.MedianResponse) * (1 + opts.QueuePenalty*float64(r.OpenRequests)))
+		r.Score = r.ExpectedFirstReview.Hours() * (1 - opts.FamiliarityWeight*r.Familiarity)
+		recommendations = append(recommendations, r)
+	}
+
+	sort.Slice(recommendations, func(i, j int) bool {
+		if recommendations[i].Score != recommendations[j].Score {
+			return recommendations[i].Score < recommendations[j].Score
+		}
+		return recommendations[i].Reviewer < recommendations[j].Reviewer
+	})
+	return recommendations
+}
+
+// familiarity scores how well reviewed paths cover files: 1 per file reviewed before, 0.5 per
+// file in a directory with reviewed files, averaged over files.
+func familiarity(reviewed map[string]bool, files []string) float64 {
+	if len(files) == 0 || len(reviewed) == 0 {
+		return 0
+	}
+	dirs := make(map[string]bool)
+	for p := range reviewed {
+		dirs[path.Dir(p)] = true
+	}
+	score := 0.0
+	for _, f := range files {
+		switch {
+		case reviewed[f]:
+			score++
+		case dirs[path.Dir(f)]:
+			score += 0.5
+		}
+	}
+	return score / float64(len(files))
+}
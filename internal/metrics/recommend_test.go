@@ -0,0 +1,55 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestRecommendReviewers(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	merged := at(500)
+
+	var prs []*github.PrData
+	reviewed := func(reviewer string, hours int, path string) {
+		created := at(len(prs) * 10)
+		prs = append(prs, &github.PrData{
+			Number: len(prs) + 1, Author: "alice", State: "closed", CreatedAt: created, MergedAt: &merged,
+			RequestedReviewers: []string{reviewer},
+			Files:              []github.FileChange{{Path: path}},
+			Reviews:            []github.Review{{Reviewer: reviewer, State: "APPROVED", SubmittedAt: created.Add(time.Duration(hours) * time.Hour)}},
+		})
+	}
+	for range 3 {
+		reviewed("bob", 4, "api/github/client.go")    // Fast, but busy and unfamiliar
+		reviewed("carol", 6, "internal/metrics/a.go") // Slower, but knows the code
+		reviewed("dave", 10, "internal/metrics/b.go")
+	}
+	reviewed("erin", 1, "internal/metrics/a.go") // Too few responses to judge
+	for range 2 {
+		prs = append(prs, &github.PrData{
+			Number: len(prs) + 1, Author: "dave", State: "open", CreatedAt: at(300),
+			RequestedReviewers: []string{"bob", "@octo/infra"},
+		})
+	}
+
+	got := metrics.RecommendReviewers(prs, "dave", []string{"internal/metrics/a.go", "internal/metrics/c.go"}, metrics.DefaultRecommendOptions, at(400))
+	if len(got) != 2 {
+		t.Fatalf("Expected bob and carol (not the author, teams or erin), got %+v", got)
+	}
+
+	carol, bob := got[0], got[1]
+	if carol.Reviewer != "carol" || bob.Reviewer != "bob" {
+		t.Fatalf("Expected carol ranked before bob, got %s then %s", got[0].Reviewer, got[1].Reviewer)
+	}
+	if carol.Familiarity != 0.75 || carol.ExpectedFirstReview != 6*time.Hour || carol.Responses != 3 {
+		t.Errorf("Unexpected recommendation for carol: %+v", carol)
+	}
+	// Two waiting requests stretch bob's 4h median by half
+	if bob.OpenRequests != 2 || bob.ExpectedFirstReview != 6*time.Hour || bob.Familiarity != 0 {
+		t.Errorf("Unexpected recommendation for bob: %+v", bob)
+	}
+}
@@ -0,0 +1,235 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// ReviewerStats summarizes the review load and responsiveness of one reviewer.
+type ReviewerStats struct {
+	Reviewer        string
+	Team            bool // Requested as a team; teams never review themselves
+	Requested       int  // PRs the reviewer was requested on
+	Reviewed        int  // PRs the reviewer reviewed, requested or not
+	Reviews         int  // Reviews submitted, including repeated reviews of the same PR
+	MedianResponse  time.Duration
+	ResponseSamples int
+	OpenRequests    int // Requests on open PRs still waiting for the reviewer
+	PeakConcurrent  int // Highest number of requests waiting for the reviewer at the same time
+}
+
+// ReviewerWorkload is the review load across a team.
+type ReviewerWorkload struct {
+	Reviewers []ReviewerStats // Busiest first
+	// Gini coefficient of reviewed PRs across individual reviewers: 0 when everyone reviews
+	// equally, approaching 1 when a single person does all reviews.
+	Gini float64
+	// TopShare is the fraction of reviewed PRs reviewed by the two busiest reviewers.
+	TopShare float64
+}
+
+// interval is the time a review request was waiting for the reviewer.
+type interval struct {
+	start, end time.Time
+}
+
+// reviewerActivity is what AnalyzeReviewerWorkload and RecommendReviewers learn about reviewers from history.
+type reviewerActivity struct {
+	stats     map[string]*ReviewerStats
+	responses map[string][]time.Duration // Time from request (or PR creation) to the first review
+	waiting   map[string][]interval      // Time requests were waiting for the reviewer
+	files     map[string]map[string]bool // Paths of files in PRs the reviewer reviewed
+}
+
+// collectReviewerActivity measures responses from the review request, or from the PR's creation
+// when the request time is unknown or the reviewer wasn't requested. Authors' reviews of their
+// own PRs are ignored.
+func collectReviewerActivity(prs []*github.PrData, now time.Time) reviewerActivity {
+	a := reviewerActivity{
+		stats:     make(map[string]*ReviewerStats),
+		responses: make(map[string][]time.Duration),
+		waiting:   make(map[string][]interval),
+		files:     make(map[string]map[string]bool),
+	}
+	get := func(reviewer string) *ReviewerStats {
+		s := a.stats[reviewer]
+		if s == nil {
+			s = &ReviewerStats{Reviewer: reviewer, Team: github.IsTeam(reviewer)}
+			a.stats[reviewer] = s
+		}
+		return s
+	}
+
+	for _, pr := range prs {
+		requestedAt := make(map[string]time.Time)
+		for _, r := range pr.RequestedReviewers {
+			requestedAt[r] = pr.CreatedAt
+		}
+		for _, e := range pr.Timeline {
+			if e.Event != "review_requested" || e.Subject == "" {
+				continue
+			}
+			if at, ok := requestedAt[e.Subject]; !ok || at.Equal(pr.CreatedAt) || e.CreatedAt.Before(at) {
+				requestedAt[e.Subject] = e.CreatedAt
+			}
+		}
+
+		reviewsBy := make(map[string][]github.Review)
+		for _, r := range pr.Reviews {
+			if r.Reviewer == "" || r.Reviewer == pr.Author {
+				continue
+			}
+			reviewsBy[r.Reviewer] = append(reviewsBy[r.Reviewer], r)
+			get(r.Reviewer).Reviews++
+		}
+
+		reviewers := make(map[string]bool)
+		for r := range requestedAt {
+			reviewers[r] = true
+		}
+		for r := range reviewsBy {
+			reviewers[r] = true
+		}
+		for reviewer := range reviewers {
+			if reviewer == pr.Author {
+				continue
+			}
+			s := get(reviewer)
+			start, requested := requestedAt[reviewer]
+			if requested {
+				s.Requested++
+			} else {
+				start = pr.CreatedAt
+			}
+			if len(reviewsBy[reviewer]) > 0 {
+				s.Reviewed++
+				if a.files[reviewer] == nil {
+					a.files[reviewer] = make(map[string]bool)
+				}
+				for _, f := range pr.Files {
+					a.files[reviewer][f.Path] = true
+				}
+			}
+
+			// The first timestamped review after the request answers it
+			var answeredAt *time.Time
+			for _, r := range reviewsBy[reviewer] {
+				if !r.SubmittedAt.IsZero() && !r.SubmittedAt.Before(start) && (answeredAt == nil || r.SubmittedAt.Before(*answeredAt)) {
+					submittedAt := r.SubmittedAt
+					answeredAt = &submittedAt
+				}
+			}
+			if answeredAt != nil {
+				a.responses[reviewer] = append(a.responses[reviewer], answeredAt.Sub(start))
+			}
+
+			if !requested {
+				continue
+			}
+			end := now
+			switch {
+			case answeredAt != nil:
+				end = *answeredAt
+			case len(reviewsBy[reviewer]) > 0:
+				// Reviewed without a timestamp: the request was answered at an unknown time
+				continue
+			case pr.MergedAt != nil:
+				end = *pr.MergedAt
+			case pr.ClosedAt != nil:
+				end = *pr.ClosedAt
+			case pr.State == "open":
+				s.OpenRequests++
+			}
+			a.waiting[reviewer] = append(a.waiting[reviewer], interval{start, end})
+		}
+	}
+	return a
+}
+
+// AnalyzeReviewerWorkload computes per-reviewer load from requested reviewers, review request
+// timeline events and reviews.
+func AnalyzeReviewerWorkload(prs []*github.PrData, now time.Time) ReviewerWorkload {
+	a := collectReviewerActivity(prs, now)
+
+	var workload ReviewerWorkload
+	var counts []float64
+	for reviewer, s := range a.stats {
+		summary := Summarize(a.responses[reviewer])
+		s.MedianResponse, s.ResponseSamples = summary.P50, summary.Count
+		s.PeakConcurrent = peakConcurrent(a.waiting[reviewer])
+		workload.Reviewers = append(workload.Reviewers, *s)
+		if !s.Team {
+			counts = append(counts, float64(s.Reviewed))
+		}
+	}
+	sort.Slice(workload.Reviewers, func(i, j int) bool {
+		x, y := workload.Reviewers[i], workload.Reviewers[j]
+		if x.Reviewed != y.Reviewed {
+			return x.Reviewed > y.Reviewed
+		}
+		if x.Requested != y.Requested {
+			return x.Requested > y.Requested
+		}
+		return x.Reviewer < y.Reviewer
+	})
+
+	workload.Gini = Gini(counts)
+	sort.Sort(sort.Reverse(sort.Float64Slice(counts)))
+	total, top := 0.0, 0.0
+	for i, c := range counts {
+		total += c
+		if i < 2 {
+			top += c
+		}
+	}
+	if total > 0 {
+		workload.TopShare = top / total
+	}
+	return workload
+}
+
+// peakConcurrent returns the largest number of overlapping intervals.
+func peakConcurrent(intervals []interval) int {
+	type edge struct {
+		at    time.Time
+		delta int
+	}
+	var edges []edge
+	for _, iv := range intervals {
+		edges = append(edges, edge{iv.start, 1}, edge{iv.end, -1})
+	}
+	// Ends sort before starts at the same instant, so back-to-back requests don't overlap
+	sort.Slice(edges, func(i, j int) bool {
+		if edges[i].at.Equal(edges[j].at) {
+			return edges[i].delta < edges[j].delta
+		}
+		return edges[i].at.Before(edges[j].at)
+	})
+	current, peak := 0, 0
+	for _, e := range edges {
+		current += e.delta
+		peak = max(peak, current)
+	}
+	return peak
+}
+
+// Gini returns the Gini coefficient of non-negative values, 0 for perfect equality.
+func Gini(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	var sum, weighted float64
+	for i, v := range sorted {
+		sum += v
+		weighted += float64(i+1) * v
+	}
+	if sum == 0 {
+		return 0
+	}
+	n := float64(len(sorted))
+	return (2*weighted)/(n*sum) - (n+1)/n
+}
@@ -0,0 +1,83 @@
+package metrics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestGini(t *testing.T) {
+	if g := metrics.Gini([]float64{5, 5, 5, 5}); g != 0 {
+		t.Errorf("Expected 0 for equal load, got %v", g)
+	}
+	if g := metrics.Gini([]float64{0, 0, 0, 12}); math.Abs(g-0.75) > 1e-9 {
+		t.Errorf("Expected 0.75 when one of four does everything, got %v", g)
+	}
+}
+
+func TestAnalyzeReviewerWorkload(t *testing.T) {
+	start := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return start.Add(time.Duration(h) * time.Hour) }
+	merged := at(100)
+	prs := []*github.PrData{
+		{
+			// bob is requested at 1h and reviews at 3h; carol reviews unrequested; alice's self-review is ignored
+			Number: 1, Author: "alice", State: "closed", CreatedAt: at(0), MergedAt: &merged,
+			RequestedReviewers: []string{"bob", "@octo/infra"},
+			Timeline:           []github.TimelineEvent{{Event: "review_requested", Subject: "bob", CreatedAt: at(1)}},
+			Reviews: []github.Review{
+				{Reviewer: "bob", State: "COMMENTED", SubmittedAt: at(3)},
+				{Reviewer: "bob", State: "APPROVED", SubmittedAt: at(20)},
+				{Reviewer: "carol", State: "APPROVED", SubmittedAt: at(5)},
+				{Reviewer: "alice", State: "COMMENTED", SubmittedAt: at(2)},
+			},
+		},
+		{
+			// bob is requested without a timeline event and reviews after 7h
+			Number: 2, Author: "carol", State: "closed", CreatedAt: at(2), MergedAt: &merged,
+			RequestedReviewers: []string{"bob"},
+			Reviews:            []github.Review{{Reviewer: "bob", State: "APPROVED", SubmittedAt: at(9)}},
+		},
+		{
+			// Still waiting for bob, overlapping both requests above
+			Number: 3, Author: "alice", State: "open", CreatedAt: at(2),
+			RequestedReviewers: []string{"bob"},
+		},
+	}
+
+	w := metrics.AnalyzeReviewerWorkload(prs, at(200))
+	byName := make(map[string]metrics.ReviewerStats)
+	for _, s := range w.Reviewers {
+		byName[s.Reviewer] = s
+	}
+	if _, ok := byName["alice"]; ok {
+		t.Error("Expected self-reviews to be ignored")
+	}
+
+	bob := byName["bob"]
+	if w.Reviewers[0].Reviewer != "bob" || bob.Requested != 3 || bob.Reviewed != 2 || bob.Reviews != 3 {
+		t.Errorf("Unexpected stats for bob: %+v", bob)
+	}
+	if bob.MedianResponse != 4*time.Hour+30*time.Minute || bob.ResponseSamples != 2 {
+		t.Errorf("Expected bob's median response of 2h and 7h to be 4h30m, got %v over %d", bob.MedianResponse, bob.ResponseSamples)
+	}
+	if bob.OpenRequests != 1 || bob.PeakConcurrent != 3 {
+		t.Errorf("Expected 1 open and 3 concurrent requests for bob, got %d and %d", bob.OpenRequests, bob.PeakConcurrent)
+	}
+
+	carol := byName["carol"]
+	if carol.Requested != 0 || carol.Reviewed != 1 || carol.MedianResponse != 5*time.Hour {
+		t.Errorf("Unexpected stats for carol: %+v", carol)
+	}
+	if team := byName["@octo/infra"]; !team.Team || team.Requested != 1 || team.Reviewed != 0 {
+		t.Errorf("Unexpected stats for the infra team: %+v", team)
+	}
+
+	// Reviewed PRs per person: bob 2, carol 1; the team is excluded from the concentration
+	if math.Abs(w.Gini-1.0/6) > 1e-9 || w.TopShare != 1 {
+		t.Errorf("Unexpected concentration: Gini %v, top share %v", w.Gini, w.TopShare)
+	}
+}
@@ -0,0 +1,119 @@
+package metrics
+
+import (
+	"sort"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// Who an open PR is waiting on.
+const (
+	WaitingOnReviewer = "reviewer" // Not reviewed yet, or updated or re-requested since the last review
+	WaitingOnAuthor   = "author"   // Reviewed since the last push
+)
+
+// StaleOptions sets when an open PR counts as stale. A zero threshold is disabled.
+type StaleOptions struct {
+	MaxAge  time.Duration // Time since the PR was opened
+	MaxIdle time.Duration // Time since the last push, review or other timeline event
+}
+
+// StalePr is an open PR that exceeds an age or idle threshold.
+type StalePr struct {
+	Pr           *PrMetrics
+	Age          time.Duration
+	Idle         time.Duration
+	LastPushAt   *time.Time
+	LastReviewAt *time.Time
+	Reviewed     bool
+	WaitingOn    string
+	// Expected is the historical 90th percentile of the next milestone for PRs of this size:
+	// time to first review for unreviewed PRs, time to merge otherwise. Zero without enough history.
+	Expected time.Duration
+	Overdue  float64 // Age as a multiple of Expected, 0 when Expected is unknown
+}
+
+// FindStale returns the open PRs among prs that exceed opts at time now, most overdue compared to
+// the historical 90th percentile first.
+func FindStale(prs []*github.PrData, history []*PrMetrics, opts StaleOptions, now time.Time) []StalePr {
+	var stale []StalePr
+	for _, pr := range prs {
+		if pr.State != "open" {
+			continue
+		}
+
+		s := StalePr{Pr: CalculateMetrics(pr), Age: now.Sub(pr.CreatedAt), WaitingOn: WaitingOnReviewer}
+		lastActivity := pr.CreatedAt
+		var lastRequest, lastReply time.Time
+		for _, e := range pr.Timeline {
+			if e.CreatedAt.After(lastActivity) {
+				lastActivity = e.CreatedAt
+			}
+			switch e.Event {
+			case "committed":
+				s.LastPushAt = latest(s.LastPushAt, e.CreatedAt)
+			case "review_requested":
+				if e.CreatedAt.After(lastRequest) {
+					lastRequest = e.CreatedAt
+				}
+			}
+		}
+		for _, r := range pr.Reviews {
+			if r.SubmittedAt.IsZero() {
+				continue
+			}
+			// Authors replying to review threads create reviews of their own; like a push, that
+			// hands the PR back to the reviewers
+			if r.Reviewer == pr.Author {
+				if r.SubmittedAt.After(lastReply) {
+					lastReply = r.SubmittedAt
+				}
+				continue
+			}
+			s.LastReviewAt = latest(s.LastReviewAt, r.SubmittedAt)
+		}
+		if lastReply.After(lastActivity) {
+			lastActivity = lastReply
+		}
+		if s.LastReviewAt != nil {
+			if s.LastReviewAt.After(lastActivity) {
+				lastActivity = *s.LastReviewAt
+			}
+			if (s.LastPushAt == nil || s.LastReviewAt.After(*s.LastPushAt)) && s.LastReviewAt.After(lastRequest) && s.LastReviewAt.After(lastReply) {
+				s.WaitingOn = WaitingOnAuthor
+			}
+		}
+		s.Idle = now.Sub(lastActivity)
+
+		if !(opts.MaxAge > 0 && s.Age > opts.MaxAge) && !(opts.MaxIdle > 0 && s.Idle > opts.MaxIdle) {
+			continue
+		}
+
+		s.Reviewed = s.LastReviewAt != nil || pr.FirstReviewedAt != nil
+		estimate := EstimateForPr(history, s.Pr)
+		s.Expected = estimate.TimeToFirstReview.P90
+		if s.Reviewed {
+			s.Expected = estimate.TimeToMerge.P90
+		}
+		if s.Expected > 0 {
+			s.Overdue = float64(s.Age) / float64(s.Expected)
+		}
+		stale = append(stale, s)
+	}
+
+	sort.SliceStable(stale, func(i, j int) bool {
+		if stale[i].Overdue != stale[j].Overdue {
+			return stale[i].Overdue > stale[j].Overdue
+		}
+		return stale[i].Age > stale[j].Age
+	})
+	return stale
+}
+
+func latest(current *time.Time, t time.Time) *time.Time {
+	if current == nil || t.After(*current) {
+		return &t
+	}
+	return current
+}
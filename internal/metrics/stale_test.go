@@ -0,0 +1,98 @@
+package metrics_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestFindStale(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	ago := func(d time.Duration) time.Time { return now.Add(-d) }
+	day := 24 * time.Hour
+
+	// History: small PRs reviewed within 1-5 hours and merged within 10-50 hours
+	var history []*metrics.PrMetrics
+	for i := 1; i <= 5; i++ {
+		history = append(history, &metrics.PrMetrics{
+			Number: i, Merged: true, Additions: 20,
+			TimeToFirstReview: time.Duration(i) * time.Hour,
+			TimeToMerge:       time.Duration(10*i) * time.Hour,
+		})
+	}
+
+	prs := []*github.PrData{
+		// Never reviewed, opened 4 days ago
+		{Number: 10, State: "open", CreatedAt: ago(4 * day), Additions: 20},
+		// Reviewed after the last push: waiting on the author
+		{
+			Number: 11, State: "open", CreatedAt: ago(6 * day), Additions: 20,
+			Timeline: []github.TimelineEvent{{Event: "committed", CreatedAt: ago(6 * day)}},
+			Reviews:  []github.Review{{Reviewer: "bob", State: "CHANGES_REQUESTED", SubmittedAt: ago(5 * day)}},
+		},
+		// Pushed after the review: waiting on the reviewer again
+		{
+			Number: 12, State: "open", CreatedAt: ago(6 * day), Additions: 20,
+			Reviews:  []github.Review{{Reviewer: "bob", State: "CHANGES_REQUESTED", SubmittedAt: ago(5 * day)}},
+			Timeline: []github.TimelineEvent{{Event: "committed", CreatedAt: ago(4 * day)}},
+		},
+		// Fresh and active: not stale
+		{Number: 13, State: "open", CreatedAt: ago(2 * time.Hour), Additions: 20},
+		// Closed PRs are never stale
+		{Number: 14, State: "closed", CreatedAt: ago(30 * day)},
+	}
+
+	stale := metrics.FindStale(prs, history, metrics.StaleOptions{MaxIdle: 3 * day}, now)
+	if len(stale) != 3 {
+		t.Fatalf("Expected 3 stale PRs, got %d", len(stale))
+	}
+
+	// #10 waits for a first review expected within ~6h: far more overdue than #11 and #12,
+	// which are compared with the ~55h merge P90
+	if stale[0].Pr.Number != 10 || stale[0].WaitingOn != metrics.WaitingOnReviewer {
+		t.Errorf("Expected #10 waiting on a reviewer first, got #%d waiting on %s", stale[0].Pr.Number, stale[0].WaitingOn)
+	}
+	if stale[0].Overdue < 10 || stale[0].Idle != 4*day {
+		t.Errorf("Unexpected overdue %.1f / idle %v for #10", stale[0].Overdue, stale[0].Idle)
+	}
+	waitingOn := map[int]string{}
+	for _, s := range stale[1:] {
+		waitingOn[s.Pr.Number] = s.WaitingOn
+		if s.Overdue < 2 || s.Overdue > 3 {
+			t.Errorf("Expected #%d to be 2-3x over the merge P90, got %.1f", s.Pr.Number, s.Overdue)
+		}
+	}
+	if waitingOn[11] != metrics.WaitingOnAuthor || waitingOn[12] != metrics.WaitingOnReviewer {
+		t.Errorf("Unexpected waiting on: %v", waitingOn)
+	}
+	if stale[2].Idle != 4*day {
+		t.Errorf("Expected #12 to be idle since its last push, got %v", stale[2].Idle)
+	}
+}
+
+func TestFindStale_AuthorRepliesHandBackToReviewer(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+
+	// The author answered the review in its threads, which GitHub records as a COMMENTED review by them
+	prs := []*github.PrData{{
+		Number: 20, State: "open", Author: "alice", CreatedAt: now.Add(-6 * day), Additions: 20,
+		Reviews: []github.Review{
+			{Reviewer: "bob", State: "CHANGES_REQUESTED", SubmittedAt: now.Add(-5 * day)},
+			{Reviewer: "alice", State: "COMMENTED", SubmittedAt: now.Add(-4 * day)},
+		},
+	}}
+
+	stale := metrics.FindStale(prs, nil, metrics.StaleOptions{MaxIdle: 3 * day}, now)
+	if len(stale) != 1 {
+		t.Fatalf("Expected 1 stale PR, got %d", len(stale))
+	}
+	if stale[0].WaitingOn != metrics.WaitingOnReviewer {
+		t.Errorf("Expected #20 waiting on a reviewer after the author's reply, got %s", stale[0].WaitingOn)
+	}
+	if stale[0].LastReviewAt == nil || !stale[0].LastReviewAt.Equal(now.Add(-5*day)) || stale[0].Idle != 4*day {
+		t.Errorf("Expected bob's review as the last review and the reply as the last activity, got %v / idle %v", stale[0].LastReviewAt, stale[0].Idle)
+	}
+}
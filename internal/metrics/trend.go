@@ -0,0 +1,190 @@
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// Trend periods.
+const (
+	PeriodWeek  = "week"  // Weeks start on Monday, UTC
+	PeriodMonth = "month" // Calendar months, UTC
+)
+
+// TrendOptions configures Trend.
+type TrendOptions struct {
+	Period string
+	// Window is the number of periods, ending with the current one, whose samples are pooled
+	// into the percentiles of each point. 1 disables smoothing.
+	Window int
+}
+
+// DurationSummary holds empirical percentiles of a set of durations.
+type DurationSummary struct {
+	Count int
+	P50   time.Duration
+	P90   time.Duration
+}
+
+// TrendPoint summarizes one period. Durations are attributed to the period in which the review
+// or merge happened, so a period reflects what the team delivered in it.
+type TrendPoint struct {
+	Start             time.Time
+	End               time.Time
+	Merged            int // PRs merged within the period itself, regardless of the window
+	TimeToFirstReview DurationSummary
+	TimeToMerge       DurationSummary
+	ReviewToMerge     DurationSummary
+	// Relative change against the previous point (0.25 = +25%); NaN when either value is missing.
+	MergedChange            float64
+	TimeToFirstReviewChange float64
+	TimeToMergeChange       float64
+	ReviewToMergeChange     float64
+}
+
+// Trend computes rolling percentiles and throughput per period, from the first to the last
+// period with a review or merge. Periods without any activity are included with empty summaries.
+func Trend(history []*PrMetrics, opts TrendOptions) ([]TrendPoint, error) {
+	if opts.Period != PeriodWeek && opts.Period != PeriodMonth {
+		return nil, fmt.Errorf("unknown period %q (expected %s or %s)", opts.Period, PeriodWeek, PeriodMonth)
+	}
+	if opts.Window < 1 {
+		return nil, fmt.Errorf("window must be at least 1, got %d", opts.Window)
+	}
+
+	type samples struct {
+		reviews, merges, reviewToMerges []time.Duration
+	}
+	byPeriod := make(map[time.Time]*samples)
+	at := func(t time.Time) *samples {
+		start := PeriodStart(t, opts.Period)
+		s := byPeriod[start]
+		if s == nil {
+			s = &samples{}
+			byPeriod[start] = s
+		}
+		return s
+	}
+	for _, m := range history {
+		if m.TimeToFirstReview > 0 {
+			s := at(m.CreatedAt.Add(m.TimeToFirstReview))
+			s.reviews = append(s.reviews, m.TimeToFirstReview)
+		}
+		if d := MergeSelector(m); d > 0 {
+			s := at(m.CreatedAt.Add(d))
+			s.merges = append(s.merges, d)
+			if m.ReviewToMerge > 0 {
+				s.reviewToMerges = append(s.reviewToMerges, m.ReviewToMerge)
+			}
+		}
+	}
+	if len(byPeriod) == 0 {
+		return nil, nil
+	}
+
+	var first, last time.Time
+	for start := range byPeriod {
+		if first.IsZero() || start.Before(first) {
+			first = start
+		}
+		if start.After(last) {
+			last = start
+		}
+	}
+	var starts []time.Time
+	for start := first; !start.After(last); start = nextPeriod(start, opts.Period) {
+		starts = append(starts, start)
+	}
+
+	points := make([]TrendPoint, len(starts))
+	for i, start := range starts {
+		var window samples
+		for j := max(0, i-opts.Window+1); j <= i; j++ {
+			if s := byPeriod[starts[j]]; s != nil {
+				window.reviews = append(window.reviews, s.reviews...)
+				window.merges = append(window.merges, s.merges...)
+				window.reviewToMerges = append(window.reviewToMerges, s.reviewToMerges...)
+			}
+		}
+
+		p := TrendPoint{
+			Start:             start,
+			End:               nextPeriod(start, opts.Period),
+			TimeToFirstReview: Summarize(window.reviews),
+			TimeToMerge:       Summarize(window.merges),
+			ReviewToMerge:     Summarize(window.reviewToMerges),
+		}
+		if s := byPeriod[start]; s != nil {
+			p.Merged = len(s.merges)
+		}
+		p.MergedChange, p.TimeToFirstReviewChange, p.TimeToMergeChange, p.ReviewToMergeChange =
+			math.NaN(), math.NaN(), math.NaN(), math.NaN()
+		if i > 0 {
+			prev := points[i-1]
+			p.MergedChange = relativeChange(float64(prev.Merged), float64(p.Merged))
+			p.TimeToFirstReviewChange = summaryChange(prev.TimeToFirstReview, p.TimeToFirstReview)
+			p.TimeToMergeChange = summaryChange(prev.TimeToMerge, p.TimeToMerge)
+			p.ReviewToMergeChange = summaryChange(prev.ReviewToMerge, p.ReviewToMerge)
+		}
+		points[i] = p
+	}
+	return points, nil
+}
+
+// PeriodStart returns the start of the period containing t.
+func PeriodStart(t time.Time, period string) time.Time {
+	t = t.UTC()
+	if period == PeriodMonth {
+		return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+	}
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+}
+
+func nextPeriod(start time.Time, period string) time.Time {
+	if period == PeriodMonth {
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 7)
+}
+
+// Summarize computes empirical percentiles of durations.
+func Summarize(durations []time.Duration) DurationSummary {
+	if len(durations) == 0 {
+		return DurationSummary{}
+	}
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return DurationSummary{
+		Count: len(sorted),
+		P50:   Percentile(sorted, 0.5),
+		P90:   Percentile(sorted, 0.9),
+	}
+}
+
+// Percentile returns the q-th quantile (0..1) of sorted durations, interpolating linearly between ranks.
+func Percentile(sorted []time.Duration, q float64) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := q * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + time.Duration((rank-float64(lo))*float64(sorted[hi]-sorted[lo]))
+}
+
+func summaryChange(prev, cur DurationSummary) float64 {
+	if prev.Count == 0 || cur.Count == 0 {
+		return math.NaN()
+	}
+	return relativeChange(float64(prev.P50), float64(cur.P50))
+}
+
+func relativeChange(prev, cur float64) float64 {
+	if prev == 0 {
+		return math.NaN()
+	}
+	return (cur - prev) / prev
+}
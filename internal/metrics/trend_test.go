@@ -0,0 +1,74 @@
+package metrics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1 * time.Hour, 2 * time.Hour, 3 * time.Hour, 4 * time.Hour, 5 * time.Hour}
+	if got := metrics.Percentile(sorted, 0.5); got != 3*time.Hour {
+		t.Errorf("Expected median 3h, got %v", got)
+	}
+	if got := metrics.Percentile(sorted, 0.9); got != 4*time.Hour+36*time.Minute {
+		t.Errorf("Expected P90 4h36m, got %v", got)
+	}
+}
+
+func TestTrend(t *testing.T) {
+	monday := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	merged := func(created time.Time, review, merge time.Duration) *metrics.PrMetrics {
+		return &metrics.PrMetrics{CreatedAt: created, Merged: true, TimeToFirstReview: review, TimeToMerge: merge, ReviewToMerge: merge - review}
+	}
+	history := []*metrics.PrMetrics{
+		// Week 1: two PRs merged after 10h and 20h
+		merged(monday, time.Hour, 10*time.Hour),
+		merged(monday, 2*time.Hour, 20*time.Hour),
+		// Week 2: nothing. Week 3: one PR merged after 5h
+		merged(monday.AddDate(0, 0, 14), time.Hour, 5*time.Hour),
+	}
+
+	points, err := metrics.Trend(history, metrics.TrendOptions{Period: metrics.PeriodWeek, Window: 1})
+	if err != nil {
+		t.Fatalf("Trend failed: %v", err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("Expected 3 weekly points including the empty week, got %d", len(points))
+	}
+	if !points[0].Start.Equal(time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Expected the first week to start on Monday 2025-03-03, got %v", points[0].Start)
+	}
+	if points[0].Merged != 2 || points[0].TimeToMerge.P50 != 15*time.Hour {
+		t.Errorf("Unexpected first week: %+v", points[0])
+	}
+	if points[1].Merged != 0 || points[1].TimeToMerge.Count != 0 || !math.IsNaN(points[1].TimeToMergeChange) {
+		t.Errorf("Expected an empty second week, got %+v", points[1])
+	}
+	if !math.IsNaN(points[0].MergedChange) {
+		t.Errorf("Expected no change for the first point, got %v", points[0].MergedChange)
+	}
+
+	rolling, err := metrics.Trend(history, metrics.TrendOptions{Period: metrics.PeriodWeek, Window: 3})
+	if err != nil {
+		t.Fatalf("Trend failed: %v", err)
+	}
+	last := rolling[2]
+	if last.Merged != 1 || last.TimeToMerge.Count != 3 || last.TimeToMerge.P50 != 10*time.Hour {
+		t.Errorf("Expected the 3-week window to pool all merges, got %+v", last)
+	}
+	if change := last.TimeToMergeChange; math.Abs(change-(10.0/15-1)) > 1e-9 {
+		t.Errorf("Expected a -33%% change against the previous window, got %v", change)
+	}
+
+	monthly, _ := metrics.Trend(history, metrics.TrendOptions{Period: metrics.PeriodMonth, Window: 1})
+	if len(monthly) != 1 || monthly[0].Merged != 3 {
+		t.Errorf("Expected a single month with 3 merges, got %+v", monthly)
+	}
+
+	if _, err := metrics.Trend(history, metrics.TrendOptions{Period: "day", Window: 1}); err == nil {
+		t.Error("Expected an error for an unknown period")
+	}
+}
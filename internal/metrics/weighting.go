@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"math"
+	"time"
+)
+
+// EstimateOptions weights historical PRs by how recently they were opened, so estimates follow
+// the current team rather than the whole history, and limits the influence of outliers. The
+// zero value weights all PRs equally and keeps every observation.
+type EstimateOptions struct {
+	HalfLife time.Duration // Age at which a PR counts half as much as a new one; 0 disables decay
+	Window   time.Duration // Ignore PRs opened longer ago than this; 0 keeps all
+	Now      time.Time     // Reference time for ages, defaults to time.Now()
+
+	Outliers      string  // OutliersTrim, OutliersWinsorize, OutliersMAD or "" to keep observations as they are
+	LowerQuantile float64 // Quantiles to trim or winsorize at, DefaultLowerQuantile and DefaultUpperQuantile when both are 0
+	UpperQuantile float64
+	Exclude       []int // PR numbers left out of estimates, e.g. PRs abandoned for months
+}
+
+// Weighted reports whether the options weight PRs differently at all.
+func (o EstimateOptions) Weighted() bool {
+	return o.HalfLife > 0 || o.Window > 0
+}
+
+// Weight returns the weight of a historical PR, from 1 for a PR opened now down to 0 for PRs
+// outside the window.
+func (o EstimateOptions) Weight(m *PrMetrics) float64 {
+	if !o.Weighted() {
+		return 1
+	}
+	now := o.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	age := max(now.Sub(m.CreatedAt), 0)
+	if o.Window > 0 && age > o.Window {
+		return 0
+	}
+	if o.HalfLife <= 0 {
+		return 1
+	}
+	return math.Exp2(-age.Hours() / o.HalfLife.Hours())
+}
+
+// effectiveWeights rescales weights so that they sum to Kish's effective sample size
+// (Σw)²/Σw². Weighted variances then use a sensible number of degrees of freedom, whatever
+// the scale of the weights. Equal weights become all ones.
+func effectiveWeights(weights []float64) ([]float64, float64) {
+	var sum, squares float64
+	for _, w := range weights {
+		sum += w
+		squares += w * w
+	}
+	if squares == 0 {
+		return weights, 0
+	}
+	scaled := make([]float64, len(weights))
+	for i, w := range weights {
+		scaled[i] = w * sum / squares
+	}
+	return scaled, sum * sum / squares
+}
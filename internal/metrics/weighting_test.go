@@ -0,0 +1,90 @@
+package metrics_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+func TestEstimateOptions_Weight(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	opts := metrics.EstimateOptions{HalfLife: 30 * 24 * time.Hour, Window: 90 * 24 * time.Hour, Now: now}
+
+	cases := []struct {
+		age      time.Duration
+		expected float64
+	}{
+		{0, 1},
+		{30 * 24 * time.Hour, 0.5},
+		{60 * 24 * time.Hour, 0.25},
+		{91 * 24 * time.Hour, 0}, // Outside the window
+	}
+	for _, c := range cases {
+		got := opts.Weight(&metrics.PrMetrics{CreatedAt: now.Add(-c.age)})
+		if math.Abs(got-c.expected) > 1e-9 {
+			t.Errorf("Weight at age %v = %f, expected %f", c.age, got, c.expected)
+		}
+	}
+	if got := (metrics.EstimateOptions{}).Weight(&metrics.PrMetrics{}); got != 1 {
+		t.Errorf("Expected the zero options to weight all PRs equally, got %f", got)
+	}
+}
+
+func TestEstimateTimesWithOptions_FavorsRecentPRs(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var history []*metrics.PrMetrics
+	// Two years ago PRs took 100h to merge; the current team merges in 10h
+	for i := 0; i < 10; i++ {
+		history = append(history,
+			&metrics.PrMetrics{Merged: true, TimeToMerge: 100 * time.Hour, CreatedAt: now.AddDate(-2, 0, -i)},
+			&metrics.PrMetrics{Merged: true, TimeToMerge: time.Duration(9+i%3) * time.Hour, CreatedAt: now.AddDate(0, 0, -i)},
+		)
+	}
+
+	unweighted := metrics.EstimateTimesUsingNormalDistribution(history, metrics.MergeSelector, "Time to Merge")
+	if unweighted.Mean < 50*time.Hour || unweighted.EffectiveSampleCount != 20 {
+		t.Errorf("Expected the unweighted mean to include old PRs, got %+v", unweighted)
+	}
+
+	decayed := metrics.EstimateTimesWithOptions(history, metrics.MergeSelector, "Time to Merge",
+		metrics.EstimateOptions{HalfLife: 30 * 24 * time.Hour, Now: now})
+	if decayed.SampleCount != 20 || decayed.Mean > 11*time.Hour {
+		t.Errorf("Expected old PRs to barely count with a 30 day half-life, got %+v", decayed)
+	}
+	if decayed.EffectiveSampleCount >= 11 || decayed.EffectiveSampleCount < 9 {
+		t.Errorf("Expected an effective sample of about the 10 recent PRs, got %.2f", decayed.EffectiveSampleCount)
+	}
+
+	windowed := metrics.EstimateTimesWithOptions(history, metrics.MergeSelector, "Time to Merge",
+		metrics.EstimateOptions{Window: 365 * 24 * time.Hour, Now: now})
+	if windowed.SampleCount != 10 || windowed.EffectiveSampleCount != 10 || windowed.Mean != 9*time.Hour+54*time.Minute {
+		t.Errorf("Expected only the 10 PRs of the last year, got %+v", windowed)
+	}
+}
+
+func TestEstimateForPrWithOptions_WindowAppliesToBuckets(t *testing.T) {
+	now := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	var history []*metrics.PrMetrics
+	for i := 0; i < 5; i++ {
+		// Enough small PRs overall, but only two of them are recent
+		created := now.AddDate(-1, 0, -i)
+		if i < 2 {
+			created = now.AddDate(0, 0, -i)
+		}
+		history = append(history,
+			&metrics.PrMetrics{Number: i, Additions: 20, Merged: true, TimeToMerge: 2 * time.Hour, CreatedAt: created},
+			&metrics.PrMetrics{Number: 100 + i, Additions: 2000, Merged: true, TimeToMerge: 48 * time.Hour, CreatedAt: now.AddDate(0, 0, -i)},
+		)
+	}
+
+	pr := &metrics.PrMetrics{Number: 999, Additions: 20}
+	if got := metrics.EstimateForPr(history, pr).BasedOn; got != "size bucket S" {
+		t.Errorf("Expected the whole history to fill size bucket S, got %q", got)
+	}
+	estimate := metrics.EstimateForPrWithOptions(history, pr, metrics.EstimateOptions{Window: 90 * 24 * time.Hour, Now: now})
+	if estimate.BasedOn != "all PRs" || estimate.TimeToMerge.SampleCount != 7 {
+		t.Errorf("Expected a fallback to the 7 PRs in the window, got %q from %d PRs", estimate.BasedOn, estimate.TimeToMerge.SampleCount)
+	}
+}
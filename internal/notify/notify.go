@@ -0,0 +1,155 @@
+// Package notify posts digests and summaries to chat and webhook endpoints.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Message is a notification with a short title and a plain-text body.
+type Message struct {
+	Title string
+	Text  string
+}
+
+// Notifier delivers a message to a single destination.
+type Notifier interface {
+	Notify(ctx context.Context, msg Message) error
+}
+
+// Webhook posts messages as JSON to an incoming webhook, retrying on network errors,
+// 429 Too Many Requests and 5xx responses with exponential backoff.
+type Webhook struct {
+	URL         string
+	Client      *http.Client
+	MaxAttempts int
+	Backoff     time.Duration // Delay before the first retry, doubled for every further retry
+
+	name    string
+	payload func(Message) any
+}
+
+// NewSlack returns a notifier for a Slack incoming webhook.
+func NewSlack(url string) *Webhook {
+	return newWebhook("Slack", url, func(msg Message) any {
+		return map[string]string{"text": "*" + msg.Title + "*\n" + msg.Text}
+	})
+}
+
+// NewTeams returns a notifier for a Microsoft Teams incoming webhook, posting a MessageCard.
+func NewTeams(url string) *Webhook {
+	return newWebhook("Teams", url, func(msg Message) any {
+		return map[string]string{
+			"@type":    "MessageCard",
+			"@context": "https://schema.org/extensions",
+			"summary":  msg.Title,
+			"title":    msg.Title,
+			// Teams renders text as Markdown, where single newlines don't break lines
+			"text": strings.ReplaceAll(msg.Text, "\n", "  \n"),
+		}
+	})
+}
+
+// NewJSON returns a notifier for any endpoint accepting {"title": ..., "text": ...}.
+func NewJSON(url string) *Webhook {
+	return newWebhook("webhook", url, func(msg Message) any {
+		return map[string]string{"title": msg.Title, "text": msg.Text}
+	})
+}
+
+func newWebhook(name, url string, payload func(Message) any) *Webhook {
+	return &Webhook{
+		URL:         url,
+		Client:      &http.Client{Timeout: 30 * time.Second},
+		MaxAttempts: 4,
+		Backoff:     time.Second,
+		name:        name,
+		payload:     payload,
+	}
+}
+
+func (w *Webhook) String() string {
+	return w.name
+}
+
+func (w *Webhook) Notify(ctx context.Context, msg Message) error {
+	body, err := json.Marshal(w.payload(msg))
+	if err != nil {
+		return err
+	}
+
+	delay := w.Backoff
+	for attempt := 1; ; attempt++ {
+		retryAfter, err := w.post(ctx, body)
+		if err == nil {
+			return nil
+		}
+		var permanent *permanentError
+		if errors.As(err, &permanent) || attempt >= w.MaxAttempts {
+			return fmt.Errorf("posting to %s: %w", w.name, err)
+		}
+
+		wait := max(delay, retryAfter)
+		log.Printf("Warning: Posting to %s failed (attempt %d of %d), retrying in %v: %v", w.name, attempt, w.MaxAttempts, wait, err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+		delay *= 2
+	}
+}
+
+// permanentError is a failure that retrying won't fix, such as a 4xx response.
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string { return e.err.Error() }
+func (e *permanentError) Unwrap() error { return e.err }
+
+// post sends body once, returning the server's Retry-After for retryable responses.
+func (w *Webhook) post(ctx context.Context, body []byte) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, &permanentError{err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return 0, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	err = fmt.Errorf("unexpected status %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	if resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode < 500 {
+		return 0, &permanentError{err}
+	}
+	seconds, _ := strconv.Atoi(resp.Header.Get("Retry-After"))
+	return time.Duration(seconds) * time.Second, err
+}
+
+// NotifyAll delivers msg to every notifier, attempting all of them even when some fail.
+func NotifyAll(ctx context.Context, notifiers []Notifier, msg Message) error {
+	var errs []error
+	for _, n := range notifiers {
+		if err := n.Notify(ctx, msg); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,130 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/notify"
+)
+
+// standIn records the JSON payloads it receives and answers with the given statuses in turn,
+// then with 200 OK.
+func standIn(t *testing.T, statuses ...int) (*httptest.Server, *[]map[string]string, *int32) {
+	var payloads []map[string]string
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&calls, 1)
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected a JSON request, got %q", r.Header.Get("Content-Type"))
+		}
+		var payload map[string]string
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+		if int(n) <= len(statuses) {
+			w.WriteHeader(statuses[n-1])
+		}
+	}))
+	t.Cleanup(ts.Close)
+	return ts, &payloads, &calls
+}
+
+var msg = notify.Message{Title: "2 stale pull requests", Text: "• #1 First\n• #2 Second"}
+
+func TestPayloads(t *testing.T) {
+	cases := []struct {
+		name     string
+		notifier func(string) *notify.Webhook
+		check    func(map[string]string) bool
+	}{
+		{"slack", notify.NewSlack, func(p map[string]string) bool {
+			return p["text"] == "*2 stale pull requests*\n• #1 First\n• #2 Second"
+		}},
+		{"teams", notify.NewTeams, func(p map[string]string) bool {
+			return p["@type"] == "MessageCard" && p["title"] == msg.Title && p["text"] == "• #1 First  \n• #2 Second"
+		}},
+		{"json", notify.NewJSON, func(p map[string]string) bool {
+			return p["title"] == msg.Title && p["text"] == msg.Text
+		}},
+	}
+	for _, c := range cases {
+		ts, payloads, _ := standIn(t)
+		if err := c.notifier(ts.URL).Notify(context.Background(), msg); err != nil {
+			t.Errorf("%s: Notify failed: %v", c.name, err)
+			continue
+		}
+		if len(*payloads) != 1 || !c.check((*payloads)[0]) {
+			t.Errorf("%s: unexpected payloads %v", c.name, *payloads)
+		}
+	}
+}
+
+func TestRetries(t *testing.T) {
+	ts, _, calls := standIn(t, http.StatusServiceUnavailable, http.StatusTooManyRequests)
+	n := notify.NewSlack(ts.URL)
+	n.Backoff = time.Millisecond
+	if err := n.Notify(context.Background(), msg); err != nil {
+		t.Fatalf("Expected the third attempt to succeed, got %v", err)
+	}
+	if *calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", *calls)
+	}
+
+	ts, _, calls = standIn(t, 500, 500, 500, 500, 500)
+	n = notify.NewJSON(ts.URL)
+	n.Backoff = time.Millisecond
+	if err := n.Notify(context.Background(), msg); err == nil || !strings.Contains(err.Error(), "500") {
+		t.Errorf("Expected the last 500 error after giving up, got %v", err)
+	}
+	if *calls != 4 {
+		t.Errorf("Expected MaxAttempts (4) attempts, got %d", *calls)
+	}
+
+	ts, _, calls = standIn(t, http.StatusNotFound)
+	if err := notify.NewTeams(ts.URL).Notify(context.Background(), msg); err == nil {
+		t.Error("Expected an error for a 404")
+	}
+	if *calls != 1 {
+		t.Errorf("Expected client errors not to be retried, got %d attempts", *calls)
+	}
+}
+
+func TestNotifyAllAttemptsEveryNotifier(t *testing.T) {
+	failing, _, _ := standIn(t, http.StatusBadRequest)
+	ok, payloads, _ := standIn(t)
+	err := notify.NotifyAll(context.Background(), []notify.Notifier{notify.NewSlack(failing.URL), notify.NewJSON(ok.URL)}, msg)
+	if err == nil {
+		t.Error("Expected the failing notifier's error")
+	}
+	if len(*payloads) != 1 {
+		t.Errorf("Expected the second notifier to be called despite the first failing")
+	}
+}
+
+func TestRender(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "message.tmpl")
+	os.WriteFile(path, []byte(`{{upper .Title}}: {{range .Data}}{{duration .}} {{end}}`), 0o644)
+	tmpl, err := notify.LoadTemplate(path)
+	if err != nil {
+		t.Fatalf("LoadTemplate failed: %v", err)
+	}
+
+	got, err := notify.Render(tmpl, "Ages", "default text", []time.Duration{3 * time.Hour, 50 * time.Hour})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if got.Title != "Ages" || got.Text != "AGES: 3h 2d 2h " {
+		t.Errorf("Unexpected message %+v", got)
+	}
+
+	if got, _ := notify.Render(nil, "Ages", "default text", nil); got.Text != "default text" {
+		t.Errorf("Expected the default text without a template, got %q", got.Text)
+	}
+}
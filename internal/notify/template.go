@@ -0,0 +1,51 @@
+package notify
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// TemplateData is passed to message templates. Text is the default rendering of the message and
+// Data the command-specific details, e.g. the stale PRs or SLA reports.
+type TemplateData struct {
+	Title string
+	Text  string
+	Data  any
+}
+
+// templateFuncs are available in message templates in addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	"duration": report.HumanDuration,
+	"percent":  func(v float64) string { return fmt.Sprintf("%.0f%%", v*100) },
+	"date":     func(t time.Time) string { return t.Format("2006-01-02") },
+	"upper":    strings.ToUpper,
+}
+
+// LoadTemplate parses a text/template file for message bodies.
+func LoadTemplate(path string) (*template.Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return template.New(path).Funcs(templateFuncs).Parse(string(data))
+}
+
+// Render builds a message from the default title and text, replacing the text with the output
+// of tmpl when it is not nil.
+func Render(tmpl *template.Template, title, text string, data any) (Message, error) {
+	msg := Message{Title: title, Text: text}
+	if tmpl == nil {
+		return msg, nil
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, TemplateData{Title: title, Text: text, Data: data}); err != nil {
+		return msg, fmt.Errorf("rendering message template: %w", err)
+	}
+	msg.Text = b.String()
+	return msg, nil
+}
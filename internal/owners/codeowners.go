@@ -0,0 +1,151 @@
+// Package owners parses CODEOWNERS files and maps changed paths to their owning users and teams.
+package owners
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/sushant-115/pr-effort-estimator/api"
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+)
+
+// Locations are where code hosts look for CODEOWNERS, in order of precedence.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// Unowned groups paths that no rule assigns an owner to.
+const Unowned = "(unowned)"
+
+// Rule assigns Owners to the paths matching Pattern.
+type Rule struct {
+	Pattern string
+	Owners  []string // Empty when the rule removes ownership
+	re      *regexp.Regexp
+}
+
+// CodeOwners is a parsed CODEOWNERS file. Like on GitHub and GitLab, the last matching rule wins.
+type CodeOwners struct {
+	Rules []Rule
+}
+
+// Parse reads a CODEOWNERS file. GitLab section headers ("[Section]") are skipped, so rules of
+// all sections are merged.
+func Parse(r io.Reader) (*CodeOwners, error) {
+	c := &CodeOwners{}
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = strings.TrimSpace(line[:i])
+		}
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		re, err := patternRegexp(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: invalid pattern %q: %w", n, fields[0], err)
+		}
+		c.Rules = append(c.Rules, Rule{Pattern: fields[0], Owners: fields[1:], re: re})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Fetch reads and parses the first CODEOWNERS file found at Locations. It returns nil without
+// an error when the repository has none, and fails when a location can't be read.
+func Fetch(ctx context.Context, source api.FileSource) (*CodeOwners, error) {
+	for _, path := range Locations {
+		content, err := source.GetFile(ctx, path, "")
+		if errors.Is(err, github.ErrFileNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading %s: %w", path, err)
+		}
+		c, err := Parse(strings.NewReader(string(content)))
+		if err != nil {
+			return nil, fmt.Errorf("parsing %s: %w", path, err)
+		}
+		return c, nil
+	}
+	return nil, nil
+}
+
+// Owners returns the owners of path, or nil if it is unowned.
+func (c *CodeOwners) Owners(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(c.Rules) - 1; i >= 0; i-- {
+		if c.Rules[i].re.MatchString(path) {
+			return c.Rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// PathOwners returns the sorted owners of any of paths, including Unowned when some path has no owner.
+func (c *CodeOwners) PathOwners(paths []string) []string {
+	seen := make(map[string]bool)
+	for _, path := range paths {
+		owners := c.Owners(path)
+		if len(owners) == 0 {
+			seen[Unowned] = true
+		}
+		for _, owner := range owners {
+			seen[owner] = true
+		}
+	}
+	result := make([]string, 0, len(seen))
+	for owner := range seen {
+		result = append(result, owner)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// patternRegexp translates a gitignore-style CODEOWNERS pattern into a regexp matching the paths
+// it covers, including everything below matched directories. Patterns without a slash other
+// than a trailing one match at any depth; "dir/*" only matches files directly in dir.
+func patternRegexp(pattern string) (*regexp.Regexp, error) {
+	shallow := strings.HasSuffix(pattern, "/*") && !strings.HasSuffix(pattern, "**/*")
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.TrimPrefix(strings.TrimSuffix(pattern, "/"), "/")
+
+	var b strings.Builder
+	b.WriteString("^")
+	if !anchored {
+		b.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch ch := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			b.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			b.WriteString(".*")
+			i++
+		case ch == '*':
+			b.WriteString("[^/]*")
+		case ch == '?':
+			b.WriteString("[^/]")
+		case ch == '\\' && i+1 < len(pattern):
+			i++
+			b.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			b.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+	if !shallow {
+		b.WriteString("(?:/.*)?")
+	}
+	b.WriteString("$")
+	return regexp.Compile(b.String())
+}
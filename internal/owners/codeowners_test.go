@@ -0,0 +1,97 @@
+package owners_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/owners"
+)
+
+const codeowners = `# Default owners
+*                   @octo/core
+
+*.md                @octo/docs  # Documentation anywhere
+/api/               @octo/api
+internal/metrics/   @octo/stats alice
+docs/*              @octo/docs
+**/testdata/**      @octo/qa
+
+[Generated]
+/api/gen/
+`
+
+func TestOwners(t *testing.T) {
+	c, err := owners.Parse(strings.NewReader(codeowners))
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(c.Rules) != 7 {
+		t.Fatalf("Expected 7 rules, got %d: %+v", len(c.Rules), c.Rules)
+	}
+
+	for path, want := range map[string][]string{
+		"main.go":                      {"@octo/core"},
+		"api/github/README.md":         {"@octo/api"}, // The later /api/ rule wins over *.md
+		"cmd/README.md":                {"@octo/docs"},
+		"internal/metrics/estimate.go": {"@octo/stats", "alice"},
+		"internal/sla/testdata/a.json": {"@octo/qa"},
+		"docs/guide.txt":               {"@octo/docs"},
+		"docs/images/logo.png":         {"@octo/core"}, // docs/* doesn't match nested files
+		"api/gen/client.go":            nil,
+		"other/api/github/client.go":   {"@octo/core"}, // /api/ is anchored to the root
+		"vendor/internal/metrics/x.go": {"@octo/core"},
+	} {
+		if got := c.Owners(path); !slices.Equal(got, want) {
+			t.Errorf("Owners(%q) = %v, want %v", path, got, want)
+		}
+	}
+
+	got := c.PathOwners([]string{"internal/metrics/a.go", "api/gen/x.go", "main.go", "internal/metrics/b.go"})
+	if want := []string{"(unowned)", "@octo/core", "@octo/stats", "alice"}; !slices.Equal(got, want) {
+		t.Errorf("PathOwners = %v, want %v", got, want)
+	}
+}
+
+// files is a FileSource serving fixed files.
+type files map[string]string
+
+func (f files) GetFile(_ context.Context, path, _ string) ([]byte, error) {
+	content, ok := f[path]
+	if !ok {
+		return nil, fmt.Errorf("%s: %w", path, github.ErrFileNotFound)
+	}
+	return []byte(content), nil
+}
+
+func TestFetch(t *testing.T) {
+	c, err := owners.Fetch(context.Background(), files{"docs/CODEOWNERS": "* @docs-first", ".github/CODEOWNERS": "* @github-first"})
+	if err != nil || c == nil {
+		t.Fatalf("Fetch failed: %v", err)
+	}
+	if got := c.Owners("a.go"); !slices.Equal(got, []string{"@github-first"}) {
+		t.Errorf("Expected .github/CODEOWNERS to take precedence, got %v", got)
+	}
+
+	if c, err := owners.Fetch(context.Background(), files{}); c != nil || err != nil {
+		t.Errorf("Expected nil without a CODEOWNERS file, got %v (err %v)", c, err)
+	}
+}
+
+// failingSource is a FileSource whose requests fail.
+type failingSource struct{}
+
+func (failingSource) GetFile(context.Context, string, string) ([]byte, error) {
+	return nil, errors.New("401 Bad credentials")
+}
+
+func TestFetch_ReportsReadErrors(t *testing.T) {
+	c, err := owners.Fetch(context.Background(), failingSource{})
+	if err == nil || !strings.Contains(err.Error(), "401 Bad credentials") {
+		t.Errorf("Expected the read error, got %v (err %v)", c, err)
+	}
+}
@@ -0,0 +1,93 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+const dateFormat = "Mon Jan 2 2006"
+
+// ForecastText renders when a batch of PRs will all be merged: percentile dates, the
+// cumulative probability per day as a bar chart, and the forecast of each PR.
+func ForecastText(source string, f metrics.Forecast) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "All %d pull requests in %s merged (%d simulations):\n", len(f.Items), source, f.Trials)
+	for _, p := range []struct {
+		name string
+		at   time.Time
+	}{{"50%", f.P50}, {"80%", f.P80}, {"90%", f.P90}, {"95%", f.P95}} {
+		fmt.Fprintf(&b, "  %s likely by %s\n", p.name, p.at.Format(dateFormat+" 15:04"))
+	}
+
+	fmt.Fprintln(&b, "\nProbability all are merged by the end of:")
+	for _, d := range f.ByDay {
+		bar := int(d.Probability*20 + 0.5)
+		fmt.Fprintf(&b, "  %s  %s%s %3.0f%%\n", d.Date.Format(dateFormat), strings.Repeat("█", bar), strings.Repeat(" ", 20-bar), d.Probability*100)
+	}
+
+	fmt.Fprintln(&b)
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "PR\tSize\tMerged by (50%)\tMerged by (90%)\tBased on")
+	for _, item := range f.Items {
+		name := "new"
+		if item.Number > 0 {
+			name = fmt.Sprintf("#%d", item.Number)
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n", name, item.SizeBucket, item.P50.Format(dateFormat), item.P90.Format(dateFormat), item.BasedOn)
+	}
+	tw.Flush()
+	return b.String()
+}
+
+type forecastItem struct {
+	Number     int       `json:"number,omitempty"`
+	Title      string    `json:"title,omitempty"`
+	SizeBucket string    `json:"size_bucket"`
+	CreatedAt  time.Time `json:"created_at,omitzero"`
+	BasedOn    string    `json:"based_on"`
+	P50        time.Time `json:"p50"`
+	P90        time.Time `json:"p90"`
+}
+
+type forecastDay struct {
+	Date        string  `json:"date"`
+	Probability float64 `json:"probability"`
+}
+
+type forecast struct {
+	Trials int            `json:"trials"`
+	P50    time.Time      `json:"p50"`
+	P80    time.Time      `json:"p80"`
+	P90    time.Time      `json:"p90"`
+	P95    time.Time      `json:"p95"`
+	ByDay  []forecastDay  `json:"by_day"`
+	Prs    []forecastItem `json:"prs"`
+}
+
+// WriteForecastJSON writes the forecast as a JSON object.
+func WriteForecastJSON(w io.Writer, f metrics.Forecast) error {
+	out := forecast{Trials: f.Trials, P50: f.P50, P80: f.P80, P90: f.P90, P95: f.P95}
+	for _, d := range f.ByDay {
+		out.ByDay = append(out.ByDay, forecastDay{Date: d.Date.Format(time.DateOnly), Probability: d.Probability})
+	}
+	for _, item := range f.Items {
+		out.Prs = append(out.Prs, forecastItem{
+			Number:     item.Number,
+			Title:      item.Title,
+			SizeBucket: item.SizeBucket,
+			CreatedAt:  item.CreatedAt,
+			BasedOn:    item.BasedOn,
+			P50:        item.P50,
+			P90:        item.P90,
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
@@ -0,0 +1,72 @@
+package report_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func testForecast() metrics.Forecast {
+	day := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+	return metrics.Forecast{
+		Trials: 1000,
+		P50:    day.Add(30 * time.Hour),
+		P80:    day.Add(40 * time.Hour),
+		P90:    day.Add(50 * time.Hour),
+		P95:    day.Add(60 * time.Hour),
+		ByDay: []metrics.ForecastDay{
+			{Date: day, Probability: 0.1},
+			{Date: day.AddDate(0, 0, 1), Probability: 0.75},
+			{Date: day.AddDate(0, 0, 2), Probability: 0.97},
+		},
+		Items: []metrics.ItemForecast{
+			{ForecastItem: metrics.ForecastItem{Number: 42, SizeBucket: metrics.SizeL}, BasedOn: "size bucket L", P50: day.Add(30 * time.Hour), P90: day.Add(50 * time.Hour)},
+			{ForecastItem: metrics.ForecastItem{SizeBucket: metrics.SizeS}, BasedOn: "all PRs", P50: day.Add(3 * time.Hour), P90: day.Add(10 * time.Hour)},
+		},
+	}
+}
+
+func TestForecastText(t *testing.T) {
+	text := report.ForecastText("octo/repo", testForecast())
+	for _, want := range []string{
+		"All 2 pull requests in octo/repo merged (1000 simulations):",
+		"50% likely by Tue Jun 3 2025 06:00",
+		"95% likely by Wed Jun 4 2025 12:00",
+		"Tue Jun 3 2025  ███████████████       75%",
+		"#42  L     Tue Jun 3 2025   Wed Jun 4 2025   size bucket L",
+		"new  S",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected text to contain %q, got:\n%s", want, text)
+		}
+	}
+}
+
+func TestWriteForecastJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := report.WriteForecastJSON(&buf, testForecast()); err != nil {
+		t.Fatalf("WriteForecastJSON failed: %v", err)
+	}
+	var decoded struct {
+		P90   time.Time `json:"p90"`
+		ByDay []struct {
+			Date        string  `json:"date"`
+			Probability float64 `json:"probability"`
+		} `json:"by_day"`
+		Prs []map[string]any `json:"prs"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("Invalid JSON: %v\n%s", err, buf.String())
+	}
+	if decoded.ByDay[1].Date != "2025-06-03" || decoded.ByDay[1].Probability != 0.75 || len(decoded.Prs) != 2 {
+		t.Errorf("Unexpected JSON:\n%s", buf.String())
+	}
+	if _, ok := decoded.Prs[1]["number"]; ok {
+		t.Errorf("Expected hypothetical PRs without a number, got %v", decoded.Prs[1])
+	}
+}
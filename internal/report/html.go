@@ -0,0 +1,209 @@
+package report
+
+import (
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// htmlReport is the data behind htmlTemplate.
+type htmlReport struct {
+	Title       string
+	GeneratedAt time.Time
+	PrCount     int
+	MergedCount int
+	Sections    []htmlSection
+	Prs         []htmlRow
+}
+
+type htmlSection struct {
+	Heading     string
+	Description string
+	Estimate    *metrics.NormalDistributionEstimates
+	Chart       template.HTML
+}
+
+type htmlRow struct {
+	Number           int
+	Title            string
+	Author           string
+	State            string
+	SizeBucket       string
+	Lines            int
+	Additions        int
+	Deletions        int
+	ChangedFiles     int
+	CreatedAt        time.Time
+	FirstReview      time.Duration
+	TimeToMerge      time.Duration
+	FirstReviewHours float64
+	TimeToMergeHours float64
+}
+
+// WriteHTML writes a self-contained HTML report of allMetrics: duration histograms with the fitted
+// normal distribution, size-vs-time scatter plots, weekly trends and a sortable per-PR table.
+// Charts are inline SVG and the table is sorted with inline JavaScript, so the file needs no
+// external assets and can be shared as is.
+func WriteHTML(w io.Writer, title string, allMetrics []*metrics.PrMetrics) error {
+	r := htmlReport{
+		Title:       title,
+		GeneratedAt: time.Now().UTC(),
+		PrCount:     len(allMetrics),
+	}
+
+	var reviews, merges []time.Duration
+	for _, m := range allMetrics {
+		if d := metrics.FirstReviewSelector(m); d > 0 {
+			reviews = append(reviews, d)
+		}
+		if d := metrics.MergeSelector(m); d > 0 {
+			merges = append(merges, d)
+			r.MergedCount++
+		}
+		r.Prs = append(r.Prs, htmlRow{
+			Number:           m.Number,
+			Title:            m.Title,
+			Author:           m.Author,
+			State:            m.State,
+			SizeBucket:       metrics.SizeBucket(m.Additions, m.Deletions),
+			Lines:            m.Additions + m.Deletions,
+			Additions:        m.Additions,
+			Deletions:        m.Deletions,
+			ChangedFiles:     m.ChangedFiles,
+			CreatedAt:        m.CreatedAt,
+			FirstReview:      m.TimeToFirstReview,
+			TimeToMerge:      metrics.MergeSelector(m),
+			FirstReviewHours: m.TimeToFirstReview.Hours(),
+			TimeToMergeHours: metrics.MergeSelector(m).Hours(),
+		})
+	}
+
+	reviewFit := metrics.EstimateTimesUsingNormalDistribution(allMetrics, metrics.FirstReviewSelector, "Time to First Review")
+	mergeFit := metrics.EstimateTimesUsingNormalDistribution(allMetrics, metrics.MergeSelector, "Time to Merge")
+	weekly, _ := metrics.Trend(allMetrics, metrics.TrendOptions{Period: metrics.PeriodWeek, Window: 1})
+	r.Sections = []htmlSection{
+		{
+			Heading:     "Time to first review",
+			Description: "Distribution of the time from PR creation to the first review. The line is the fitted normal distribution the estimates are based on.",
+			Estimate:    &reviewFit,
+			Chart:       histogramChart(reviews, reviewFit),
+		},
+		{
+			Heading:     "Time to merge",
+			Description: "Distribution of the time from PR creation to merge, for merged PRs.",
+			Estimate:    &mergeFit,
+			Chart:       histogramChart(merges, mergeFit),
+		},
+		{
+			Heading:     "Size vs. time to first review",
+			Description: "Changed lines (log scale) against time to first review.",
+			Chart:       scatterChart(allMetrics, metrics.FirstReviewSelector),
+		},
+		{
+			Heading:     "Size vs. time to merge",
+			Description: "Changed lines (log scale) against time to merge, for merged PRs.",
+			Chart:       scatterChart(allMetrics, metrics.MergeSelector),
+		},
+		{
+			Heading:     "Weekly trend",
+			Description: "Weekly medians, by the week in which the review or merge happened.",
+			Chart: trendChart(map[string][]weeklyPoint{
+				"Time to first review": weeklySeries(weekly, func(p metrics.TrendPoint) metrics.DurationSummary { return p.TimeToFirstReview }),
+				"Time to merge":        weeklySeries(weekly, func(p metrics.TrendPoint) metrics.DurationSummary { return p.TimeToMerge }),
+			}),
+		},
+	}
+	return htmlTemplate.Execute(w, r)
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"duration": func(d time.Duration) string {
+		if d <= 0 {
+			return "–"
+		}
+		return HumanDuration(d)
+	},
+	"hours": func(v float64) float64 { return float64(int(v*100)) / 100 },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>{{.Title}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em auto; max-width: 1100px; color: #24292f; padding: 0 1em; }
+h1 { margin-bottom: 0.2em; }
+.meta, .description { color: #57606a; }
+.chart { width: 100%; max-width: 800px; height: auto; display: block; }
+.axis { stroke: #57606a; }
+.grid { stroke: #d0d7de; stroke-dasharray: 2 3; }
+.tick { font-size: 11px; fill: #57606a; }
+.bar { fill: #54aeff; }
+.fit { fill: none; stroke: #cf222e; stroke-width: 2; }
+.point { fill: #0969da; fill-opacity: 0.6; }
+.line { fill: none; stroke-width: 2; }
+.legend { font-size: 12px; }
+.series0 { fill: #0969da; stroke: #0969da; }
+.series1 { fill: #bf8700; stroke: #bf8700; }
+polyline.series0, polyline.series1 { fill: none; }
+text.legend { stroke: none; }
+table { border-collapse: collapse; width: 100%; font-size: 13px; }
+th, td { border-bottom: 1px solid #d0d7de; padding: 4px 8px; text-align: left; }
+th { cursor: pointer; user-select: none; background: #f6f8fa; position: sticky; top: 0; }
+th[data-dir="asc"]::after { content: " ▲"; }
+th[data-dir="desc"]::after { content: " ▼"; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>{{.Title}}</h1>
+<p class="meta">{{.PrCount}} pull requests, {{.MergedCount}} merged. Generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}.</p>
+{{range .Sections}}
+<section>
+<h2>{{.Heading}}</h2>
+<p class="description">{{.Description}}{{with .Estimate}}{{if .SampleCount}} Based on {{.SampleCount}} PRs: median {{duration .P50}}, 80th percentile {{duration .P80}}, 90th percentile {{duration .P90}}.{{end}}{{end}}</p>
+{{if .Chart}}{{.Chart}}{{else}}<p>Not enough data.</p>{{end}}
+</section>
+{{end}}
+<section>
+<h2>Pull requests</h2>
+<p class="description">Click a column header to sort.</p>
+<table id="prs">
+<thead><tr>
+<th data-type="number">#</th><th>Title</th><th>Author</th><th>State</th><th data-type="number">Size</th>
+<th data-type="number">+/-</th><th data-type="number">Files</th><th>Created</th>
+<th data-type="number">First review</th><th data-type="number">Merge</th>
+</tr></thead>
+<tbody>
+{{range .Prs}}<tr>
+<td class="num" data-value="{{.Number}}">{{.Number}}</td><td>{{.Title}}</td><td>{{.Author}}</td><td>{{.State}}</td>
+<td data-value="{{.Lines}}">{{.SizeBucket}}</td><td class="num" data-value="{{.Lines}}">+{{.Additions}} / -{{.Deletions}}</td>
+<td class="num" data-value="{{.ChangedFiles}}">{{.ChangedFiles}}</td><td>{{.CreatedAt.Format "2006-01-02"}}</td>
+<td class="num" data-value="{{hours .FirstReviewHours}}">{{duration .FirstReview}}</td><td class="num" data-value="{{hours .TimeToMergeHours}}">{{duration .TimeToMerge}}</td>
+</tr>
+{{end}}</tbody>
+</table>
+</section>
+<script>
+document.querySelectorAll("#prs th").forEach(function (th, column) {
+  th.addEventListener("click", function () {
+    var dir = th.dataset.dir === "asc" ? "desc" : "asc";
+    document.querySelectorAll("#prs th").forEach(function (other) { delete other.dataset.dir; });
+    th.dataset.dir = dir;
+    var numeric = th.dataset.type === "number";
+    var tbody = document.querySelector("#prs tbody");
+    var rows = Array.from(tbody.rows);
+    rows.sort(function (a, b) {
+      var x = a.cells[column].dataset.value || a.cells[column].textContent;
+      var y = b.cells[column].dataset.value || b.cells[column].textContent;
+      var cmp = numeric ? parseFloat(x) - parseFloat(y) : x.localeCompare(y);
+      return dir === "asc" ? cmp : -cmp;
+    });
+    rows.forEach(function (row) { tbody.appendChild(row); });
+  });
+});
+</script>
+</body>
+</html>
+`))
@@ -0,0 +1,52 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestWriteHTML(t *testing.T) {
+	created := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	var ms []*metrics.PrMetrics
+	for i := 1; i <= 6; i++ {
+		ms = append(ms, &metrics.PrMetrics{
+			Number: i, Title: "Fix <thing>", Author: "octocat", State: "closed", Merged: true,
+			CreatedAt:         created.AddDate(0, 0, 7*i),
+			TimeToFirstReview: time.Duration(i) * time.Hour,
+			TimeToMerge:       time.Duration(10*i) * time.Hour,
+			Additions:         10 * i, Deletions: i, ChangedFiles: 2,
+		})
+	}
+	ms = append(ms, &metrics.PrMetrics{Number: 99, Title: "Open", State: "open", CreatedAt: created})
+
+	var b strings.Builder
+	if err := report.WriteHTML(&b, "octo/repo", ms); err != nil {
+		t.Fatalf("WriteHTML failed: %v", err)
+	}
+	out := b.String()
+
+	for _, want := range []string{
+		"<title>octo/repo</title>",
+		"7 pull requests, 6 merged",
+		`<polyline class="fit"`,
+		`<circle class="point"`,
+		`<polyline class="line series0"`,
+		`<td class="num" data-value="99">99</td>`,
+		"Fix &lt;thing&gt;",
+		"<script>",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Expected report to contain %q", want)
+		}
+	}
+	if strings.Contains(out, "Fix <thing>") {
+		t.Error("Expected PR titles to be escaped")
+	}
+	if strings.Contains(out, "src=\"http") || strings.Contains(out, "href=\"http") {
+		t.Error("Expected no external assets")
+	}
+}
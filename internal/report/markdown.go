@@ -0,0 +1,110 @@
+// Package report renders metrics and estimates for humans: Markdown for PR comments and job summaries,
+// and self-contained HTML reports.
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// EstimateMarker identifies comments written by the estimator so they can be updated in place.
+const EstimateMarker = "<!-- pr-effort-estimator -->"
+
+// EstimateMarkdown renders the expected review and merge times of a PR as a Markdown table.
+func EstimateMarkdown(pr *metrics.PrMetrics, estimate metrics.PrEstimate) string {
+	var b strings.Builder
+	fmt.Fprintln(&b, EstimateMarker)
+	fmt.Fprintln(&b, "### Estimated review timeline")
+	fmt.Fprintln(&b)
+	fmt.Fprintf(&b, "Size: **%s** (+%d / -%d in %d files). ", estimate.SizeBucket, pr.Additions, pr.Deletions, pr.ChangedFiles)
+	if estimate.TimeToMerge.SampleCount == 0 && estimate.TimeToFirstReview.SampleCount == 0 {
+		fmt.Fprintln(&b, "Not enough historical data to estimate this PR yet.")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Based on %s.\n\n", estimate.BasedOn)
+
+	fmt.Fprintln(&b, "| | Median | 90th percentile | Sample |")
+	fmt.Fprintln(&b, "|---|---|---|---|")
+	writeRow := func(name string, e metrics.NormalDistributionEstimates) {
+		if e.SampleCount == 0 {
+			fmt.Fprintf(&b, "| %s | n/a | n/a | 0 PRs |\n", name)
+			return
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s | %s |\n", name, HumanDuration(e.P50), HumanDuration(e.P90), sample(e))
+	}
+	writeRow("Time to first review", estimate.TimeToFirstReview)
+	writeRow("Time to merge", estimate.TimeToMerge)
+	return b.String()
+}
+
+// sample describes the PRs an estimate is based on, with how many were excluded or treated as
+// outliers, e.g. "19 PRs, 1 excluded, 2 outliers".
+func sample(e metrics.NormalDistributionEstimates) string {
+	text := fmt.Sprintf("%d PRs", e.SampleCount)
+	if e.Excluded > 0 {
+		text += fmt.Sprintf(", %d excluded", e.Excluded)
+	}
+	if e.Outliers > 0 {
+		text += fmt.Sprintf(", %d outliers", e.Outliers)
+	}
+	return text
+}
+
+// HumanDuration formats a duration with its two most significant units, e.g. "2d 3h" or "45m".
+func HumanDuration(d time.Duration) string {
+	if d <= 0 {
+		return "0m"
+	}
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0 && hours > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case days > 0:
+		return fmt.Sprintf("%dd", days)
+	case hours > 0 && minutes > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	case hours > 0:
+		return fmt.Sprintf("%dh", hours)
+	default:
+		return fmt.Sprintf("%dm", minutes)
+	}
+}
+
+// LocalEstimateText renders the expected review and merge times of unpushed local changes as
+// plain text. split is the estimate for a change half the size, shown for L and XL changes when
+// it is quicker to merge, so the author can decide whether to split them.
+func LocalEstimateText(source, base string, pr *metrics.PrMetrics, estimate, split metrics.PrEstimate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s (%s..HEAD)\n", pr.Title, base)
+	fmt.Fprintf(&b, "Size: %s (+%d / -%d in %d files)\n", estimate.SizeBucket, pr.Additions, pr.Deletions, pr.ChangedFiles)
+	if len(pr.Labels) > 0 {
+		fmt.Fprintf(&b, "Labels: %s\n", strings.Join(pr.Labels, ", "))
+	}
+	if estimate.TimeToMerge.SampleCount == 0 && estimate.TimeToFirstReview.SampleCount == 0 {
+		fmt.Fprintf(&b, "Not enough historical data in %s to estimate this change yet.\n", source)
+		return b.String()
+	}
+	fmt.Fprintf(&b, "Based on %s in %s.\n\n", estimate.BasedOn, source)
+
+	writeLine := func(name string, e metrics.NormalDistributionEstimates) {
+		if e.SampleCount == 0 {
+			fmt.Fprintf(&b, "%-22s n/a\n", name+":")
+			return
+		}
+		fmt.Fprintf(&b, "%-22s %s median, %s 90th percentile (%s)\n", name+":", HumanDuration(e.P50), HumanDuration(e.P90), sample(e))
+	}
+	writeLine("Time to first review", estimate.TimeToFirstReview)
+	writeLine("Time to merge", estimate.TimeToMerge)
+
+	if (estimate.SizeBucket == metrics.SizeL || estimate.SizeBucket == metrics.SizeXL) && split.SizeBucket != estimate.SizeBucket &&
+		split.TimeToMerge.SampleCount > 0 && split.TimeToMerge.P50 < estimate.TimeToMerge.P50 {
+		fmt.Fprintf(&b, "\nSplit into two %s PRs, each would take about %s to merge (median, based on %s).\n",
+			split.SizeBucket, HumanDuration(split.TimeToMerge.P50), split.BasedOn)
+	}
+	return b.String()
+}
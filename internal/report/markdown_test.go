@@ -0,0 +1,82 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestHumanDuration(t *testing.T) {
+	cases := map[time.Duration]string{
+		0:                            "0m",
+		45 * time.Minute:             "45m",
+		3 * time.Hour:                "3h",
+		3*time.Hour + 20*time.Minute: "3h 20m",
+		51 * time.Hour:               "2d 3h",
+		72 * time.Hour:               "3d",
+	}
+	for d, expected := range cases {
+		if got := report.HumanDuration(d); got != expected {
+			t.Errorf("HumanDuration(%v) = %q, expected %q", d, got, expected)
+		}
+	}
+}
+
+func TestEstimateMarkdown(t *testing.T) {
+	pr := &metrics.PrMetrics{Number: 7, Additions: 30, Deletions: 5, ChangedFiles: 3}
+	estimate := metrics.PrEstimate{
+		Number:            7,
+		SizeBucket:        metrics.SizeS,
+		BasedOn:           "size bucket S",
+		TimeToFirstReview: metrics.NormalDistributionEstimates{SampleCount: 8, P50: 4 * time.Hour, P90: 10 * time.Hour},
+		TimeToMerge:       metrics.NormalDistributionEstimates{SampleCount: 8, P50: 26 * time.Hour, P90: 60 * time.Hour, Excluded: 1, Outliers: 2},
+	}
+
+	md := report.EstimateMarkdown(pr, estimate)
+	if !strings.HasPrefix(md, report.EstimateMarker) {
+		t.Errorf("Expected the comment to start with the marker, got:\n%s", md)
+	}
+	for _, expected := range []string{"Based on size bucket S", "| Time to first review | 4h | 10h | 8 PRs |", "| Time to merge | 1d 2h | 2d 12h | 8 PRs, 1 excluded, 2 outliers |"} {
+		if !strings.Contains(md, expected) {
+			t.Errorf("Expected %q in:\n%s", expected, md)
+		}
+	}
+}
+
+func TestLocalEstimateText(t *testing.T) {
+	pr := &metrics.PrMetrics{Title: "Add cache", Additions: 700, Deletions: 100, ChangedFiles: 12, Labels: []string{"backend"}}
+	estimate := metrics.PrEstimate{
+		SizeBucket:        metrics.SizeL,
+		BasedOn:           "size bucket L labelled backend",
+		TimeToFirstReview: metrics.NormalDistributionEstimates{SampleCount: 6, P50: 20 * time.Hour, P90: 50 * time.Hour},
+		TimeToMerge:       metrics.NormalDistributionEstimates{SampleCount: 6, P50: 96 * time.Hour, P90: 200 * time.Hour, Outliers: 1},
+	}
+	split := metrics.PrEstimate{
+		SizeBucket:  metrics.SizeM,
+		BasedOn:     "size bucket M",
+		TimeToMerge: metrics.NormalDistributionEstimates{SampleCount: 9, P50: 30 * time.Hour},
+	}
+
+	text := report.LocalEstimateText("octo/repo", "origin/main", pr, estimate, split)
+	for _, want := range []string{
+		"Add cache (origin/main..HEAD)",
+		"Size: L (+700 / -100 in 12 files)",
+		"Labels: backend",
+		"Based on size bucket L labelled backend in octo/repo.",
+		"Time to first review:  20h median, 2d 2h 90th percentile (6 PRs)",
+		"Time to merge:         4d median, 8d 8h 90th percentile (6 PRs, 1 outliers)",
+		"Split into two M PRs, each would take about 1d 6h to merge",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Expected text to contain %q, got:\n%s", want, text)
+		}
+	}
+
+	small := report.LocalEstimateText("octo/repo", "main", pr, metrics.PrEstimate{SizeBucket: metrics.SizeS}, split)
+	if !strings.Contains(small, "Not enough historical data in octo/repo") || strings.Contains(small, "Split") {
+		t.Errorf("Expected no estimate without history, got:\n%s", small)
+	}
+}
@@ -0,0 +1,37 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// GroupLatencyTable renders review latency per group as an aligned plain-text table, with group
+// naming the first column (e.g. "Owner" or "Directory").
+func GroupLatencyTable(group string, groups []metrics.GroupLatency) string {
+	if len(groups) == 0 {
+		return "No pull requests with changed file data found.\n"
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tPRs\tOpen\tFirst review p50\tFirst review p90\tMerge p50\tMerge p90\t\n", group)
+	for _, g := range groups {
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%s\t%s\t%s\t%s\t\n", g.Group, g.Prs, g.Open,
+			summaryDuration(g.TimeToFirstReview, g.TimeToFirstReview.P50), summaryDuration(g.TimeToFirstReview, g.TimeToFirstReview.P90),
+			summaryDuration(g.TimeToMerge, g.TimeToMerge.P50), summaryDuration(g.TimeToMerge, g.TimeToMerge.P90))
+	}
+	tw.Flush()
+	return b.String()
+}
+
+// summaryDuration formats a percentile of s, or "n/a" when s has no samples.
+func summaryDuration(s metrics.DurationSummary, d time.Duration) string {
+	if s.Count == 0 {
+		return "n/a"
+	}
+	return HumanDuration(d)
+}
@@ -0,0 +1,21 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestGroupLatencyTable(t *testing.T) {
+	table := report.GroupLatencyTable("Owner", []metrics.GroupLatency{
+		{Group: "@octo/api", Prs: 3, Open: 1, TimeToFirstReview: metrics.DurationSummary{Count: 2, P50: 4 * time.Hour, P90: 30 * time.Hour}},
+	})
+	for _, want := range []string{"Owner  PRs  Open", "@octo/api    3     1                4h             1d 6h", "n/a"} {
+		if !strings.Contains(table, want) {
+			t.Errorf("Expected table to contain %q, got:\n%s", want, table)
+		}
+	}
+}
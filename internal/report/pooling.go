@@ -0,0 +1,27 @@
+package report
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// PooledEstimateTable renders partially pooled estimates per group as an aligned plain-text
+// table, with group naming the first column (e.g. "Author" or "Label").
+func PooledEstimateTable(group string, p metrics.PooledEstimate) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Repository median: %s over %d PRs. Group medians typically differ from it by a factor of %.1f (1 = groups don't differ).\n\n",
+		HumanDuration(p.RepoMedian), p.SampleCount, math.Exp(p.BetweenStdDev))
+
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(tw, "%s\tPRs\tOwn median\tEstimate\t%.0f%% interval\tNext PR p90\tPooled\t\n", group, metrics.PosteriorLevel*100)
+	for _, g := range p.Groups {
+		fmt.Fprintf(tw, "%s\t%d\t%s\t%s\t%s – %s\t%s\t%.0f%%\t\n", g.Group, g.SampleCount, HumanDuration(g.RawMedian), HumanDuration(g.Median),
+			HumanDuration(g.MedianLow), HumanDuration(g.MedianHigh), HumanDuration(g.P90), g.Shrinkage*100)
+	}
+	tw.Flush()
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestPooledEstimateTable(t *testing.T) {
+	table := report.PooledEstimateTable("Author", metrics.PooledEstimate{
+		SampleCount:   26,
+		RepoMedian:    20 * time.Hour,
+		BetweenStdDev: 0.6931471805599453,
+		Groups: []metrics.GroupEstimate{
+			{Group: "carol", SampleCount: 2, RawMedian: 190 * time.Hour, Median: 100 * time.Hour, MedianLow: 40 * time.Hour, MedianHigh: 250 * time.Hour, P90: 400 * time.Hour, Shrinkage: 0.4},
+		},
+	})
+	for _, want := range []string{
+		"Repository median: 20h over 26 PRs",
+		"factor of 2.0",
+		"Author  PRs  Own median  Estimate      90% interval  Next PR p90  Pooled",
+		"carol    2      7d 22h     4d 4h  1d 16h – 10d 10h      16d 16h     40%",
+	} {
+		if !strings.Contains(table, want) {
+			t.Errorf("Expected table to contain %q, got:\n%s", want, table)
+		}
+	}
+}
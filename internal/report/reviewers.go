@@ -0,0 +1,50 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// ReviewerWorkloadTable renders the review load per reviewer as an aligned plain-text table,
+// followed by how concentrated reviews are across the team.
+func ReviewerWorkloadTable(w metrics.ReviewerWorkload) string {
+	if len(w.Reviewers) == 0 {
+		return "No reviews or review requests found.\n"
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "Reviewer\tRequested\tReviewed PRs\tReviews\tMedian response\tOpen requests\tPeak concurrent\t")
+	for _, s := range w.Reviewers {
+		response := "n/a"
+		if s.ResponseSamples > 0 {
+			response = HumanDuration(s.MedianResponse)
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%s\t%d\t%d\t\n", s.Reviewer, s.Requested, s.Reviewed, s.Reviews, response, s.OpenRequests, s.PeakConcurrent)
+	}
+	tw.Flush()
+
+	fmt.Fprintf(&b, "\nGini coefficient of reviewed PRs: %.2f (0 = evenly shared, 1 = one person reviews everything)\n", w.Gini)
+	fmt.Fprintf(&b, "Share of reviewed PRs by the two busiest reviewers: %.0f%%\n", w.TopShare*100)
+	return b.String()
+}
+
+// RecommendationTable renders ranked reviewer recommendations as an aligned plain-text table.
+func RecommendationTable(recommendations []metrics.ReviewerRecommendation) string {
+	if len(recommendations) == 0 {
+		return "No reviewers with enough review history to recommend.\n"
+	}
+
+	var b strings.Builder
+	tw := tabwriter.NewWriter(&b, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "#\tReviewer\tExpected first review\tMedian response\tP90 response\tResponses\tOpen requests\tFamiliarity\t")
+	for i, r := range recommendations {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%d\t%d\t%.0f%%\t\n", i+1, r.Reviewer, HumanDuration(r.ExpectedFirstReview),
+			HumanDuration(r.MedianResponse), HumanDuration(r.P90Response), r.Responses, r.OpenRequests, r.Familiarity*100)
+	}
+	tw.Flush()
+	return b.String()
+}
@@ -0,0 +1,31 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestReviewerWorkloadTable(t *testing.T) {
+	table := report.ReviewerWorkloadTable(metrics.ReviewerWorkload{
+		Reviewers: []metrics.ReviewerStats{
+			{Reviewer: "bob", Requested: 3, Reviewed: 2, Reviews: 3, MedianResponse: 4 * time.Hour, ResponseSamples: 2, OpenRequests: 1, PeakConcurrent: 3},
+			{Reviewer: "@octo/infra", Team: true, Requested: 1},
+		},
+		Gini:     0.17,
+		TopShare: 1,
+	})
+	for _, want := range []string{
+		"bob          3             2        3               4h              1                3",
+		"@octo/infra          1             0        0              n/a              0                0",
+		"Gini coefficient of reviewed PRs: 0.17",
+		"two busiest reviewers: 100%",
+	} {
+		if !strings.Contains(table, want) {
+			t.Errorf("Expected table to contain %q, got:\n%s", want, table)
+		}
+	}
+}
@@ -0,0 +1,47 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/sla"
+)
+
+// SLASummary renders SLA reports as plain text: compliance overall and per period, breaching PRs
+// and open PRs at risk of breaching, as of now.
+func SLASummary(reports []sla.RuleReport, period string, now time.Time) string {
+	var b strings.Builder
+	for i, r := range reports {
+		if i > 0 {
+			fmt.Fprintln(&b)
+		}
+		fmt.Fprintf(&b, "SLA: %s\n", r.Rule.Name)
+		if r.Met+r.Breached == 0 {
+			fmt.Fprintln(&b, "No matching PRs to evaluate yet.")
+		} else {
+			fmt.Fprintf(&b, "Compliance: %.1f%% (%d met, %d breached)\n", r.Compliance*100, r.Met, r.Breached)
+			for _, p := range r.Periods {
+				fmt.Fprintf(&b, "  %s %s: %5.1f%% (%d of %d)\n", period, p.Start.Format("2006-01-02"), p.Compliance*100, p.Met, p.Met+p.Breached)
+			}
+		}
+
+		if len(r.Breaches) > 0 {
+			fmt.Fprintln(&b, "Breaches:")
+		}
+		for _, br := range r.Breaches {
+			if br.CompletedAt != nil {
+				fmt.Fprintf(&b, "• #%d %s: due %s, %s late\n", br.Pr.Number, br.Pr.Title, br.Deadline.Format("2006-01-02 15:04"), HumanDuration(br.CompletedAt.Sub(br.Deadline)))
+			} else {
+				fmt.Fprintf(&b, "• #%d %s: due %s, still open and %s overdue\n", br.Pr.Number, br.Pr.Title, br.Deadline.Format("2006-01-02 15:04"), HumanDuration(now.Sub(br.Deadline)))
+			}
+		}
+		if len(r.AtRisk) > 0 {
+			fmt.Fprintln(&b, "At risk:")
+		}
+		for _, a := range r.AtRisk {
+			fmt.Fprintf(&b, "• #%d %s: due %s, %s left\n", a.Pr.Number, a.Pr.Title, a.Deadline.Format("2006-01-02 15:04"), HumanDuration(a.Deadline.Sub(now)))
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,40 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+	"github.com/sushant-115/pr-effort-estimator/internal/sla"
+)
+
+func TestSLASummary(t *testing.T) {
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC)
+	reviewed := now.Add(-20 * time.Hour)
+	reports := []sla.RuleReport{{
+		Rule:       sla.Rule{Name: "First review within 1 business day"},
+		Met:        3,
+		Breached:   1,
+		Compliance: 0.75,
+		Periods:    []sla.PeriodCompliance{{Start: time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC), Met: 3, Breached: 1, Compliance: 0.75}},
+		Breaches: []sla.Result{{
+			Pr: &metrics.PrMetrics{Number: 4, Title: "Slow"}, Status: sla.StatusBreached,
+			Deadline: now.Add(-26 * time.Hour), CompletedAt: &reviewed,
+		}},
+		AtRisk: []sla.Result{{Pr: &metrics.PrMetrics{Number: 5, Title: "Pending"}, Status: sla.StatusAtRisk, Deadline: now.Add(3 * time.Hour)}},
+	}}
+
+	summary := report.SLASummary(reports, metrics.PeriodWeek, now)
+	for _, want := range []string{
+		"SLA: First review within 1 business day\nCompliance: 75.0% (3 met, 1 breached)",
+		"week 2025-03-10:  75.0% (3 of 4)",
+		"• #4 Slow: due 2025-03-13 10:00, 6h late",
+		"At risk:\n• #5 Pending: due 2025-03-14 15:00, 3h left",
+	} {
+		if !strings.Contains(summary, want) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", want, summary)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// StaleDigest renders stale PRs as a plain-text nudge, grouped by who they are waiting on,
+// that reads well in Slack, email and terminals alike.
+func StaleDigest(repo string, stale []metrics.StalePr) string {
+	var b strings.Builder
+	switch len(stale) {
+	case 0:
+		fmt.Fprintf(&b, "No stale pull requests in %s.\n", repo)
+		return b.String()
+	case 1:
+		fmt.Fprintf(&b, "1 stale pull request in %s\n", repo)
+	default:
+		fmt.Fprintf(&b, "%d stale pull requests in %s\n", len(stale), repo)
+	}
+
+	for _, group := range []struct {
+		waitingOn, heading string
+	}{
+		{metrics.WaitingOnReviewer, "Waiting on reviewers"},
+		{metrics.WaitingOnAuthor, "Waiting on authors"},
+	} {
+		var lines []string
+		for _, s := range stale {
+			if s.WaitingOn != group.waitingOn {
+				continue
+			}
+			line := fmt.Sprintf("• #%d %s", s.Pr.Number, s.Pr.Title)
+			if s.Pr.Author != "" {
+				line += " (" + s.Pr.Author + ")"
+			}
+			line += fmt.Sprintf(": open %s, idle %s", HumanDuration(s.Age), HumanDuration(s.Idle))
+			if s.Expected > 0 {
+				milestone := "first review"
+				if s.Reviewed {
+					milestone = "merge"
+				}
+				line += fmt.Sprintf(", %.1fx the usual %s time of %s", s.Overdue, milestone, HumanDuration(s.Expected))
+			}
+			lines = append(lines, line)
+		}
+		if len(lines) > 0 {
+			fmt.Fprintf(&b, "\n%s:\n%s\n", group.heading, strings.Join(lines, "\n"))
+		}
+	}
+	return b.String()
+}
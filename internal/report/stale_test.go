@@ -0,0 +1,39 @@
+package report_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestStaleDigest(t *testing.T) {
+	stale := []metrics.StalePr{
+		{
+			Pr:  &metrics.PrMetrics{Number: 10, Title: "Add cache", Author: "alice"},
+			Age: 96 * time.Hour, Idle: 96 * time.Hour, WaitingOn: metrics.WaitingOnReviewer,
+			Expected: 6 * time.Hour, Overdue: 16,
+		},
+		{
+			Pr:  &metrics.PrMetrics{Number: 11, Title: "Fix login", Author: "carol"},
+			Age: 144 * time.Hour, Idle: 120 * time.Hour, WaitingOn: metrics.WaitingOnAuthor, Reviewed: true,
+		},
+	}
+
+	digest := report.StaleDigest("octo/repo", stale)
+	for _, want := range []string{
+		"2 stale pull requests in octo/repo",
+		"Waiting on reviewers:\n• #10 Add cache (alice): open 4d, idle 4d, 16.0x the usual first review time of 6h",
+		"Waiting on authors:\n• #11 Fix login (carol): open 6d, idle 5d",
+	} {
+		if !strings.Contains(digest, want) {
+			t.Errorf("Expected digest to contain %q, got:\n%s", want, digest)
+		}
+	}
+
+	if digest := report.StaleDigest("octo/repo", nil); digest != "No stale pull requests in octo/repo.\n" {
+		t.Errorf("Unexpected empty digest %q", digest)
+	}
+}
@@ -0,0 +1,264 @@
+package report
+
+import (
+	"fmt"
+	"html"
+	"html/template"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"gonum.org/v1/gonum/stat/distuv"
+)
+
+// Chart geometry in SVG user units; charts scale to the page width via their viewBox.
+const (
+	chartWidth   = 640
+	chartHeight  = 260
+	marginLeft   = 56
+	marginRight  = 12
+	marginTop    = 12
+	marginBottom = 36
+)
+
+// plot maps data coordinates onto the drawing area of a chart and accumulates its SVG elements.
+type plot struct {
+	b                      strings.Builder
+	xMin, xMax, yMin, yMax float64
+}
+
+func newPlot(xMin, xMax, yMin, yMax float64) *plot {
+	if xMax <= xMin {
+		xMax = xMin + 1
+	}
+	if yMax <= yMin {
+		yMax = yMin + 1
+	}
+	p := &plot{xMin: xMin, xMax: xMax, yMin: yMin, yMax: yMax}
+	fmt.Fprintf(&p.b, `<svg class="chart" viewBox="0 0 %d %d" xmlns="http://www.w3.org/2000/svg">`, chartWidth, chartHeight)
+	fmt.Fprintf(&p.b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, marginLeft, chartHeight-marginBottom, chartWidth-marginRight, chartHeight-marginBottom)
+	fmt.Fprintf(&p.b, `<line class="axis" x1="%d" y1="%d" x2="%d" y2="%d"/>`, marginLeft, marginTop, marginLeft, chartHeight-marginBottom)
+	return p
+}
+
+func (p *plot) x(v float64) float64 {
+	return marginLeft + (v-p.xMin)/(p.xMax-p.xMin)*(chartWidth-marginLeft-marginRight)
+}
+
+func (p *plot) y(v float64) float64 {
+	return chartHeight - marginBottom - (v-p.yMin)/(p.yMax-p.yMin)*(chartHeight-marginTop-marginBottom)
+}
+
+func (p *plot) xTick(v float64, label string) {
+	fmt.Fprintf(&p.b, `<text class="tick" x="%.1f" y="%d" text-anchor="middle">%s</text>`, p.x(v), chartHeight-marginBottom+16, html.EscapeString(label))
+}
+
+func (p *plot) yTick(v float64, label string) {
+	fmt.Fprintf(&p.b, `<line class="grid" x1="%d" y1="%.1f" x2="%d" y2="%.1f"/>`, marginLeft, p.y(v), chartWidth-marginRight, p.y(v))
+	fmt.Fprintf(&p.b, `<text class="tick" x="%d" y="%.1f" text-anchor="end">%s</text>`, marginLeft-6, p.y(v)+4, html.EscapeString(label))
+}
+
+// polyline draws a connected line through points given in data coordinates.
+func (p *plot) polyline(class string, xs, ys []float64) {
+	points := make([]string, len(xs))
+	for i := range xs {
+		points[i] = fmt.Sprintf("%.1f,%.1f", p.x(xs[i]), p.y(ys[i]))
+	}
+	fmt.Fprintf(&p.b, `<polyline class="%s" points="%s"/>`, class, strings.Join(points, " "))
+}
+
+func (p *plot) svg() template.HTML {
+	p.b.WriteString(`</svg>`)
+	return template.HTML(p.b.String())
+}
+
+// durationTicks labels about five evenly spaced hour values between 0 and max.
+func durationTicks(max float64) []float64 {
+	step := niceStep(max / 5)
+	var ticks []float64
+	for v := 0.0; v <= max+step/1e6; v += step {
+		ticks = append(ticks, v)
+	}
+	return ticks
+}
+
+// niceStep rounds a raw tick step up to 1, 2 or 5 times a power of ten.
+func niceStep(raw float64) float64 {
+	if raw <= 0 {
+		return 1
+	}
+	pow := math.Pow(10, math.Floor(math.Log10(raw)))
+	for _, m := range []float64{1, 2, 5, 10} {
+		if raw <= m*pow {
+			return m * pow
+		}
+	}
+	return 10 * pow
+}
+
+func hoursLabel(h float64) string {
+	return HumanDuration(time.Duration(h * float64(time.Hour)))
+}
+
+// histogramChart draws a histogram of durations with the fitted normal distribution scaled to the
+// bar heights, so it is easy to see how well the estimates match the actual shape of the data.
+func histogramChart(durations []time.Duration, fit metrics.NormalDistributionEstimates) template.HTML {
+	if len(durations) == 0 {
+		return ""
+	}
+	hours := make([]float64, len(durations))
+	maxHours := 0.0
+	for i, d := range durations {
+		hours[i] = d.Hours()
+		maxHours = math.Max(maxHours, hours[i])
+	}
+	bins := int(math.Ceil(math.Sqrt(float64(len(hours)))))
+	bins = max(5, min(30, bins))
+	width := maxHours / float64(bins)
+	if width == 0 {
+		width = 1
+	}
+	counts := make([]int, bins)
+	for _, h := range hours {
+		counts[min(bins-1, int(h/width))]++
+	}
+
+	yMax := 0.0
+	for _, c := range counts {
+		yMax = math.Max(yMax, float64(c))
+	}
+	var curveX, curveY []float64
+	if fit.SampleCount > 1 && fit.StdDev > 0 {
+		norm := distuv.Normal{Mu: fit.Mean.Hours(), Sigma: fit.StdDev.Hours()}
+		for i := 0; i <= 100; i++ {
+			x := float64(i) / 100 * width * float64(bins)
+			y := norm.Prob(x) * float64(len(hours)) * width
+			curveX = append(curveX, x)
+			curveY = append(curveY, y)
+			yMax = math.Max(yMax, y)
+		}
+	}
+
+	p := newPlot(0, width*float64(bins), 0, yMax*1.1)
+	for _, v := range durationTicks(yMax) {
+		p.yTick(v, fmt.Sprintf("%g", v))
+	}
+	for i, c := range counts {
+		x0, x1 := p.x(float64(i)*width), p.x(float64(i+1)*width)
+		fmt.Fprintf(&p.b, `<rect class="bar" x="%.1f" y="%.1f" width="%.1f" height="%.1f"><title>%s – %s: %d PRs</title></rect>`,
+			x0+1, p.y(float64(c)), math.Max(x1-x0-2, 1), p.y(0)-p.y(float64(c)),
+			hoursLabel(float64(i)*width), hoursLabel(float64(i+1)*width), c)
+	}
+	for _, v := range durationTicks(width * float64(bins)) {
+		p.xTick(v, hoursLabel(v))
+	}
+	if curveX != nil {
+		p.polyline("fit", curveX, curveY)
+	}
+	return p.svg()
+}
+
+// scatterChart plots PR size (changed lines, log scale) against a duration.
+func scatterChart(ms []*metrics.PrMetrics, selector func(*metrics.PrMetrics) time.Duration) template.HTML {
+	type point struct {
+		lines float64
+		hours float64
+		pr    *metrics.PrMetrics
+	}
+	var points []point
+	maxLines, maxHours := 1.0, 0.0
+	for _, m := range ms {
+		d := selector(m)
+		if d <= 0 {
+			continue
+		}
+		lines := math.Max(1, float64(m.Additions+m.Deletions))
+		points = append(points, point{lines, d.Hours(), m})
+		maxLines = math.Max(maxLines, lines)
+		maxHours = math.Max(maxHours, d.Hours())
+	}
+	if len(points) == 0 {
+		return ""
+	}
+
+	p := newPlot(0, math.Ceil(math.Log10(maxLines)), 0, maxHours*1.1)
+	for _, v := range durationTicks(maxHours) {
+		p.yTick(v, hoursLabel(v))
+	}
+	for e := 0.0; e <= p.xMax; e++ {
+		p.xTick(e, fmt.Sprintf("%g", math.Pow(10, e)))
+	}
+	for _, pt := range points {
+		fmt.Fprintf(&p.b, `<circle class="point" cx="%.1f" cy="%.1f" r="3"><title>#%d %s: %g lines, %s</title></circle>`,
+			p.x(math.Log10(pt.lines)), p.y(pt.hours), pt.pr.Number, html.EscapeString(pt.pr.Title), pt.lines, hoursLabel(pt.hours))
+	}
+	return p.svg()
+}
+
+// weeklyPoint is the median of a duration over the PRs completed in one week.
+type weeklyPoint struct {
+	Week   time.Time
+	Median time.Duration
+	Count  int
+}
+
+// weeklySeries picks the weeks of a weekly trend in which summary has data.
+func weeklySeries(trend []metrics.TrendPoint, summary func(metrics.TrendPoint) metrics.DurationSummary) []weeklyPoint {
+	var points []weeklyPoint
+	for _, p := range trend {
+		if s := summary(p); s.Count > 0 {
+			points = append(points, weeklyPoint{Week: p.Start, Median: s.P50, Count: s.Count})
+		}
+	}
+	return points
+}
+
+// trendChart draws one line per series of weekly medians on a shared time axis.
+func trendChart(series map[string][]weeklyPoint) template.HTML {
+	var first, last time.Time
+	maxHours := 0.0
+	for _, points := range series {
+		for _, pt := range points {
+			if first.IsZero() || pt.Week.Before(first) {
+				first = pt.Week
+			}
+			if pt.Week.After(last) {
+				last = pt.Week
+			}
+			maxHours = math.Max(maxHours, pt.Median.Hours())
+		}
+	}
+	if first.IsZero() {
+		return ""
+	}
+
+	weeks := func(t time.Time) float64 { return t.Sub(first).Hours() / (7 * 24) }
+	p := newPlot(0, weeks(last), 0, maxHours*1.1)
+	for _, v := range durationTicks(maxHours) {
+		p.yTick(v, hoursLabel(v))
+	}
+	step := math.Max(1, math.Ceil(weeks(last)/6))
+	for w := 0.0; w <= weeks(last); w += step {
+		p.xTick(w, first.AddDate(0, 0, int(w)*7).Format("2006-01-02"))
+	}
+
+	names := make([]string, 0, len(series))
+	for name := range series {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for i, name := range names {
+		var xs, ys []float64
+		for _, pt := range series[name] {
+			xs = append(xs, weeks(pt.Week))
+			ys = append(ys, pt.Median.Hours())
+			fmt.Fprintf(&p.b, `<circle class="series%d" cx="%.1f" cy="%.1f" r="2.5"><title>%s week of %s: median %s over %d PRs</title></circle>`,
+				i, p.x(weeks(pt.Week)), p.y(pt.Median.Hours()), html.EscapeString(name), pt.Week.Format("2006-01-02"), HumanDuration(pt.Median), pt.Count)
+		}
+		p.polyline(fmt.Sprintf("line series%d", i), xs, ys)
+		fmt.Fprintf(&p.b, `<text class="legend series%d" x="%d" y="%d">%s</text>`, i, marginLeft+10, marginTop+14+16*i, html.EscapeString(name))
+	}
+	return p.svg()
+}
@@ -0,0 +1,171 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// trendSummary is the JSON form of DurationSummary, with durations in hours.
+type trendSummary struct {
+	Count    int     `json:"count"`
+	P50Hours float64 `json:"p50_hours"`
+	P90Hours float64 `json:"p90_hours"`
+	// Relative change of the median against the previous period; null when not comparable.
+	Change *float64 `json:"change"`
+}
+
+type trendPoint struct {
+	Start             time.Time    `json:"start"`
+	End               time.Time    `json:"end"`
+	Merged            int          `json:"merged"`
+	MergedChange      *float64     `json:"merged_change"`
+	TimeToFirstReview trendSummary `json:"time_to_first_review"`
+	TimeToMerge       trendSummary `json:"time_to_merge"`
+	ReviewToMerge     trendSummary `json:"review_to_merge"`
+}
+
+func newTrendSummary(s metrics.DurationSummary, change float64) trendSummary {
+	return trendSummary{Count: s.Count, P50Hours: s.P50.Hours(), P90Hours: s.P90.Hours(), Change: optional(change)}
+}
+
+func optional(v float64) *float64 {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return nil
+	}
+	return &v
+}
+
+// WriteTrendJSON writes the trend as a JSON array with one object per period.
+func WriteTrendJSON(w io.Writer, points []metrics.TrendPoint) error {
+	out := make([]trendPoint, 0, len(points))
+	for _, p := range points {
+		out = append(out, trendPoint{
+			Start:             p.Start,
+			End:               p.End,
+			Merged:            p.Merged,
+			MergedChange:      optional(p.MergedChange),
+			TimeToFirstReview: newTrendSummary(p.TimeToFirstReview, p.TimeToFirstReviewChange),
+			TimeToMerge:       newTrendSummary(p.TimeToMerge, p.TimeToMergeChange),
+			ReviewToMerge:     newTrendSummary(p.ReviewToMerge, p.ReviewToMergeChange),
+		})
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// WriteTrendCSV writes the trend as CSV with one row per period. Durations are in hours and
+// changes are left empty when not comparable.
+func WriteTrendCSV(w io.Writer, points []metrics.TrendPoint) error {
+	cw := csv.NewWriter(w)
+	header := []string{"start", "end", "merged", "merged_change"}
+	for _, name := range []string{"time_to_first_review", "time_to_merge", "review_to_merge"} {
+		header = append(header, name+"_count", name+"_p50_hours", name+"_p90_hours", name+"_change")
+	}
+	cw.Write(header)
+
+	number := func(v float64) string {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return ""
+		}
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	for _, p := range points {
+		row := []string{p.Start.Format("2006-01-02"), p.End.Format("2006-01-02"), strconv.Itoa(p.Merged), number(p.MergedChange)}
+		for _, s := range []struct {
+			summary metrics.DurationSummary
+			change  float64
+		}{
+			{p.TimeToFirstReview, p.TimeToFirstReviewChange},
+			{p.TimeToMerge, p.TimeToMergeChange},
+			{p.ReviewToMerge, p.ReviewToMergeChange},
+		} {
+			row = append(row, strconv.Itoa(s.summary.Count), number(s.summary.P50.Hours()), number(s.summary.P90.Hours()), number(s.change))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+var sparkBlocks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a line of block characters scaled between their minimum and maximum.
+// NaN values, e.g. periods without data, render as spaces.
+func Sparkline(values []float64) string {
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, v := range values {
+		if !math.IsNaN(v) {
+			lo, hi = math.Min(lo, v), math.Max(hi, v)
+		}
+	}
+	var b strings.Builder
+	for _, v := range values {
+		switch {
+		case math.IsNaN(v):
+			b.WriteRune(' ')
+		case hi == lo:
+			b.WriteRune(sparkBlocks[len(sparkBlocks)/2])
+		default:
+			b.WriteRune(sparkBlocks[int((v-lo)/(hi-lo)*float64(len(sparkBlocks)-1)+0.5)])
+		}
+	}
+	return b.String()
+}
+
+// TrendSparklines renders one sparkline per series with its latest value and latest change,
+// for a quick look at the trend in a terminal.
+func TrendSparklines(points []metrics.TrendPoint) string {
+	if len(points) == 0 {
+		return "No reviews or merges to compute a trend from.\n"
+	}
+	last := points[len(points)-1]
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d periods from %s to %s\n", len(points), points[0].Start.Format("2006-01-02"), last.End.AddDate(0, 0, -1).Format("2006-01-02"))
+
+	series := []struct {
+		name    string
+		summary func(metrics.TrendPoint) metrics.DurationSummary
+		change  float64
+	}{
+		{"Median time to first review", func(p metrics.TrendPoint) metrics.DurationSummary { return p.TimeToFirstReview }, last.TimeToFirstReviewChange},
+		{"Median time to merge", func(p metrics.TrendPoint) metrics.DurationSummary { return p.TimeToMerge }, last.TimeToMergeChange},
+		{"Median review to merge", func(p metrics.TrendPoint) metrics.DurationSummary { return p.ReviewToMerge }, last.ReviewToMergeChange},
+	}
+	for _, s := range series {
+		values := make([]float64, len(points))
+		for i, p := range points {
+			values[i] = math.NaN()
+			if summary := s.summary(p); summary.Count > 0 {
+				values[i] = summary.P50.Hours()
+			}
+		}
+		latest := "n/a"
+		if summary := s.summary(last); summary.Count > 0 {
+			latest = HumanDuration(summary.P50)
+		}
+		fmt.Fprintf(&b, "%-28s %s  %s%s\n", s.name, Sparkline(values), latest, formatChange(s.change))
+	}
+
+	merged := make([]float64, len(points))
+	for i, p := range points {
+		merged[i] = float64(p.Merged)
+	}
+	fmt.Fprintf(&b, "%-28s %s  %d%s\n", "Merged per period", Sparkline(merged), last.Merged, formatChange(last.MergedChange))
+	return b.String()
+}
+
+func formatChange(change float64) string {
+	if math.IsNaN(change) || math.IsInf(change, 0) {
+		return ""
+	}
+	return fmt.Sprintf(" (%+.0f%%)", change*100)
+}
@@ -0,0 +1,78 @@
+package report_test
+
+import (
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+func TestSparkline(t *testing.T) {
+	if got := report.Sparkline([]float64{1, 2, math.NaN(), 8}); got != "▁▂ █" {
+		t.Errorf("Unexpected sparkline %q", got)
+	}
+	if got := report.Sparkline([]float64{3, 3}); got != "▅▅" {
+		t.Errorf("Unexpected sparkline for constant values %q", got)
+	}
+}
+
+func trendPoints() []metrics.TrendPoint {
+	start := time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)
+	return []metrics.TrendPoint{
+		{
+			Start: start, End: start.AddDate(0, 0, 7), Merged: 4,
+			TimeToMerge:  metrics.DurationSummary{Count: 4, P50: 10 * time.Hour, P90: 20 * time.Hour},
+			MergedChange: math.NaN(), TimeToFirstReviewChange: math.NaN(), TimeToMergeChange: math.NaN(), ReviewToMergeChange: math.NaN(),
+		},
+		{
+			Start: start.AddDate(0, 0, 7), End: start.AddDate(0, 0, 14), Merged: 2,
+			TimeToMerge:  metrics.DurationSummary{Count: 2, P50: 5 * time.Hour, P90: 6 * time.Hour},
+			MergedChange: -0.5, TimeToFirstReviewChange: math.NaN(), TimeToMergeChange: -0.5, ReviewToMergeChange: math.NaN(),
+		},
+	}
+}
+
+func TestWriteTrendCSV(t *testing.T) {
+	var b strings.Builder
+	if err := report.WriteTrendCSV(&b, trendPoints()); err != nil {
+		t.Fatalf("WriteTrendCSV failed: %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("Expected a header and 2 rows, got:\n%s", b.String())
+	}
+	if expected := "2025-03-10,2025-03-17,2,-0.5,0,0,0,,2,5,6,-0.5,0,0,0,"; lines[2] != expected {
+		t.Errorf("Unexpected row:\n got %s\nwant %s", lines[2], expected)
+	}
+}
+
+func TestWriteTrendJSON(t *testing.T) {
+	var b strings.Builder
+	if err := report.WriteTrendJSON(&b, trendPoints()); err != nil {
+		t.Fatalf("WriteTrendJSON failed: %v", err)
+	}
+	var out []struct {
+		MergedChange *float64 `json:"merged_change"`
+		TimeToMerge  struct {
+			P50Hours float64  `json:"p50_hours"`
+			Change   *float64 `json:"change"`
+		} `json:"time_to_merge"`
+	}
+	if err := json.Unmarshal([]byte(b.String()), &out); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if out[0].MergedChange != nil || out[1].TimeToMerge.Change == nil || *out[1].TimeToMerge.Change != -0.5 || out[1].TimeToMerge.P50Hours != 5 {
+		t.Errorf("Unexpected JSON:\n%s", b.String())
+	}
+}
+
+func TestTrendSparklines(t *testing.T) {
+	out := report.TrendSparklines(trendPoints())
+	if !strings.Contains(out, "Median time to merge         █▁  5h (-50%)") {
+		t.Errorf("Unexpected sparklines:\n%s", out)
+	}
+}
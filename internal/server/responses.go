@@ -0,0 +1,126 @@
+package server
+
+import (
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// durationStats is the JSON form of NormalDistributionEstimates, with durations in hours.
+type durationStats struct {
+	SampleCount int     `json:"sample_count"`
+	MeanHours   float64 `json:"mean_hours"`
+	StdDevHours float64 `json:"stddev_hours"`
+	P50Hours    float64 `json:"p50_hours"`
+	P80Hours    float64 `json:"p80_hours"`
+	P90Hours    float64 `json:"p90_hours"`
+	P95Hours    float64 `json:"p95_hours"`
+}
+
+func newDurationStats(e metrics.NormalDistributionEstimates) durationStats {
+	return durationStats{
+		SampleCount: e.SampleCount,
+		MeanHours:   e.Mean.Hours(),
+		StdDevHours: e.StdDev.Hours(),
+		P50Hours:    e.P50.Hours(),
+		P80Hours:    e.P80.Hours(),
+		P90Hours:    e.P90.Hours(),
+		P95Hours:    e.P95.Hours(),
+	}
+}
+
+// prMetricsResponse is the JSON form of PrMetrics; zero durations mean "not applicable".
+type prMetricsResponse struct {
+	Number                 int       `json:"number"`
+	Title                  string    `json:"title"`
+	Author                 string    `json:"author"`
+	State                  string    `json:"state"`
+	Merged                 bool      `json:"merged"`
+	CreatedAt              time.Time `json:"created_at"`
+	Additions              int       `json:"additions"`
+	Deletions              int       `json:"deletions"`
+	ChangedFiles           int       `json:"changed_files"`
+	SizeBucket             string    `json:"size_bucket"`
+	TimeToFirstReviewHours float64   `json:"time_to_first_review_hours,omitempty"`
+	TimeToMergeHours       float64   `json:"time_to_merge_hours,omitempty"`
+	ReviewToMergeHours     float64   `json:"review_to_merge_hours,omitempty"`
+}
+
+func newPrMetricsResponse(m *metrics.PrMetrics) prMetricsResponse {
+	return prMetricsResponse{
+		Number:                 m.Number,
+		Title:                  m.Title,
+		Author:                 m.Author,
+		State:                  m.State,
+		Merged:                 m.Merged,
+		CreatedAt:              m.CreatedAt,
+		Additions:              m.Additions,
+		Deletions:              m.Deletions,
+		ChangedFiles:           m.ChangedFiles,
+		SizeBucket:             metrics.SizeBucket(m.Additions, m.Deletions),
+		TimeToFirstReviewHours: m.TimeToFirstReview.Hours(),
+		TimeToMergeHours:       m.TimeToMerge.Hours(),
+		ReviewToMergeHours:     m.ReviewToMerge.Hours(),
+	}
+}
+
+type metricsResponse struct {
+	Repo                    string              `json:"repo"`
+	RefreshedAt             time.Time           `json:"refreshed_at"`
+	PrCount                 int                 `json:"pr_count"`
+	MergedCount             int                 `json:"merged_count"`
+	AverageTimeToMergeHours float64             `json:"average_time_to_merge_hours"`
+	Prs                     []prMetricsResponse `json:"prs"`
+}
+
+type bucketDistribution struct {
+	PrCount           int           `json:"pr_count"`
+	TimeToFirstReview durationStats `json:"time_to_first_review"`
+	TimeToMerge       durationStats `json:"time_to_merge"`
+}
+
+type distributionResponse struct {
+	Repo              string                        `json:"repo"`
+	RefreshedAt       time.Time                     `json:"refreshed_at"`
+	TimeToFirstReview durationStats                 `json:"time_to_first_review"`
+	TimeToMerge       durationStats                 `json:"time_to_merge"`
+	ReviewToMerge     durationStats                 `json:"review_to_merge"`
+	BySizeBucket      map[string]bucketDistribution `json:"by_size_bucket"`
+}
+
+type estimateResponse struct {
+	Repo                  string        `json:"repo"`
+	Number                int           `json:"number"`
+	Title                 string        `json:"title"`
+	State                 string        `json:"state"`
+	SizeBucket            string        `json:"size_bucket"`
+	BasedOn               string        `json:"based_on"`
+	AgeHours              float64       `json:"age_hours"`
+	TimeToFirstReview     durationStats `json:"time_to_first_review"`
+	TimeToMerge           durationStats `json:"time_to_merge"`
+	ExpectedFirstReviewAt *time.Time    `json:"expected_first_review_at,omitempty"` // Only for PRs not reviewed yet
+	ExpectedMergeAt       *time.Time    `json:"expected_merge_at,omitempty"`        // Only for PRs not merged yet
+}
+
+func newEstimateResponse(repo string, pr *metrics.PrMetrics, estimate metrics.PrEstimate, now time.Time) estimateResponse {
+	resp := estimateResponse{
+		Repo:              repo,
+		Number:            pr.Number,
+		Title:             pr.Title,
+		State:             pr.State,
+		SizeBucket:        estimate.SizeBucket,
+		BasedOn:           estimate.BasedOn,
+		AgeHours:          now.Sub(pr.CreatedAt).Hours(),
+		TimeToFirstReview: newDurationStats(estimate.TimeToFirstReview),
+		TimeToMerge:       newDurationStats(estimate.TimeToMerge),
+	}
+	if pr.TimeToFirstReview == 0 && estimate.TimeToFirstReview.SampleCount > 0 {
+		at := pr.CreatedAt.Add(estimate.TimeToFirstReview.P50)
+		resp.ExpectedFirstReviewAt = &at
+	}
+	if !pr.Merged && pr.State == "open" && estimate.TimeToMerge.SampleCount > 0 {
+		at := pr.CreatedAt.Add(estimate.TimeToMerge.P50)
+		resp.ExpectedMergeAt = &at
+	}
+	return resp
+}
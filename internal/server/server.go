@@ -0,0 +1,165 @@
+// Package server exposes PR metrics and estimates over a JSON REST API.
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+type Server struct {
+	store           *Store
+	refreshInterval time.Duration
+	estimateOptions metrics.EstimateOptions
+	mux             *http.ServeMux
+}
+
+// New serves the store's snapshots, estimating PRs with estimateOptions.
+func New(store *Store, refreshInterval time.Duration, estimateOptions metrics.EstimateOptions) *Server {
+	s := &Server{
+		store:           store,
+		refreshInterval: refreshInterval,
+		estimateOptions: estimateOptions,
+		mux:             http.NewServeMux(),
+	}
+	s.mux.HandleFunc("GET /repos/{owner}/{repo}/metrics", s.handleMetrics)
+	s.mux.HandleFunc("GET /repos/{owner}/{repo}/prs/{number}/estimate", s.handleEstimate)
+	s.mux.HandleFunc("GET /repos/{owner}/{repo}/distribution", s.handleDistribution)
+	return s
+}
+
+// Handle registers an additional handler, e.g. for webhooks or metrics exporters.
+func (s *Server) Handle(pattern string, handler http.Handler) {
+	s.mux.Handle(pattern, handler)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshot(w, r)
+	if !ok {
+		return
+	}
+
+	resp := metricsResponse{
+		Repo:        snap.Repo,
+		RefreshedAt: snap.RefreshedAt,
+		PrCount:     len(snap.Metrics),
+		Prs:         make([]prMetricsResponse, 0, len(snap.Metrics)),
+	}
+	var totalTimeToMerge time.Duration
+	for _, m := range snap.Metrics {
+		resp.Prs = append(resp.Prs, newPrMetricsResponse(m))
+		if d := metrics.MergeSelector(m); d > 0 {
+			totalTimeToMerge += d
+			resp.MergedCount++
+		}
+	}
+	if resp.MergedCount > 0 {
+		resp.AverageTimeToMergeHours = (totalTimeToMerge / time.Duration(resp.MergedCount)).Hours()
+	}
+	s.writeJSON(w, r, snap, resp)
+}
+
+func (s *Server) handleEstimate(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshot(w, r)
+	if !ok {
+		return
+	}
+	number, err := strconv.Atoi(r.PathValue("number"))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, "invalid PR number %q", r.PathValue("number"))
+		return
+	}
+
+	var pr *metrics.PrMetrics
+	for _, m := range snap.Metrics {
+		if m.Number == number {
+			pr = m
+			break
+		}
+	}
+	if pr == nil {
+		writeError(w, http.StatusNotFound, "PR #%d not found in %s", number, snap.Repo)
+		return
+	}
+
+	s.writeJSON(w, r, snap, newEstimateResponse(snap.Repo, pr, metrics.EstimateForPrWithOptions(snap.Metrics, pr, s.estimateOptions), time.Now()))
+}
+
+func (s *Server) handleDistribution(w http.ResponseWriter, r *http.Request) {
+	snap, ok := s.snapshot(w, r)
+	if !ok {
+		return
+	}
+
+	resp := distributionResponse{
+		Repo:              snap.Repo,
+		RefreshedAt:       snap.RefreshedAt,
+		TimeToFirstReview: newDurationStats(metrics.EstimateTimesUsingNormalDistribution(snap.Metrics, metrics.FirstReviewSelector, "Time to First Review")),
+		TimeToMerge:       newDurationStats(metrics.EstimateTimesUsingNormalDistribution(snap.Metrics, metrics.MergeSelector, "Time to Merge")),
+		ReviewToMerge: newDurationStats(metrics.EstimateTimesUsingNormalDistribution(snap.Metrics, func(m *metrics.PrMetrics) time.Duration {
+			return m.ReviewToMerge
+		}, "Review to Merge")),
+		BySizeBucket: make(map[string]bucketDistribution),
+	}
+
+	buckets := make(map[string][]*metrics.PrMetrics)
+	for _, m := range snap.Metrics {
+		bucket := metrics.SizeBucket(m.Additions, m.Deletions)
+		buckets[bucket] = append(buckets[bucket], m)
+	}
+	for bucket, ms := range buckets {
+		resp.BySizeBucket[bucket] = bucketDistribution{
+			PrCount:           len(ms),
+			TimeToFirstReview: newDurationStats(metrics.EstimateTimesUsingNormalDistribution(ms, metrics.FirstReviewSelector, "Time to First Review ("+bucket+")")),
+			TimeToMerge:       newDurationStats(metrics.EstimateTimesUsingNormalDistribution(ms, metrics.MergeSelector, "Time to Merge ("+bucket+")")),
+		}
+	}
+	s.writeJSON(w, r, snap, resp)
+}
+
+// snapshot resolves the repository of the request, answering 404 when it isn't served or not yet fetched.
+func (s *Server) snapshot(w http.ResponseWriter, r *http.Request) (*Snapshot, bool) {
+	repo := r.PathValue("owner") + "/" + r.PathValue("repo")
+	snap, ok := s.store.Snapshot(repo)
+	if !ok {
+		writeError(w, http.StatusNotFound, "repository %s is not served or has not been fetched yet", repo)
+	}
+	return snap, ok
+}
+
+// writeJSON encodes v with caching headers tied to the snapshot, answering 304 for fresh conditional requests.
+func (s *Server) writeJSON(w http.ResponseWriter, r *http.Request, snap *Snapshot, v any) {
+	etag := fmt.Sprintf(`"%x"`, snap.RefreshedAt.UnixNano())
+	maxAge := int(time.Until(snap.RefreshedAt.Add(s.refreshInterval)).Seconds())
+	if maxAge < 0 {
+		maxAge = 0
+	}
+
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Last-Modified", snap.RefreshedAt.UTC().Format(http.TimeFormat))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	if r.Header.Get("If-None-Match") == etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		log.Printf("Warning: Could not write response for %s: %v", r.URL.Path, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, status int, format string, args ...any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": fmt.Sprintf(format, args...)})
+}
@@ -0,0 +1,162 @@
+package server_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/server"
+)
+
+// newTestServer serves octo/repo with five merged PRs and one open PR (#100).
+func newTestServer(t *testing.T, opts metrics.EstimateOptions) *httptest.Server {
+	now := time.Now()
+	var prs []*github.PrData
+	for i := 1; i <= 5; i++ {
+		created := now.Add(-time.Duration(100+i) * time.Hour)
+		reviewed := created.Add(time.Duration(i) * time.Hour)
+		merged := created.Add(time.Duration(10*i) * time.Hour)
+		prs = append(prs, &github.PrData{
+			Number: i, Title: "merged", State: "closed", CreatedAt: created,
+			FirstReviewedAt: &reviewed, MergedAt: &merged, ClosedAt: &merged, Additions: 20,
+		})
+	}
+	prs = append(prs, &github.PrData{Number: 100, Title: "open", State: "open", CreatedAt: now.Add(-2 * time.Hour), Additions: 30})
+
+	store := server.NewStore([]string{"octo/repo"}, func(ctx context.Context, repo string) ([]*github.PrData, error) {
+		return prs, nil
+	})
+	if err := store.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh failed: %v", err)
+	}
+
+	ts := httptest.NewServer(server.New(store, time.Hour, opts))
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+func getJSON(t *testing.T, url string, v any) *http.Response {
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("GET %s failed: %v", url, err)
+	}
+	defer resp.Body.Close()
+	if v != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("Decoding %s failed: %v", url, err)
+		}
+	}
+	return resp
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	ts := newTestServer(t, metrics.EstimateOptions{})
+
+	var body struct {
+		PrCount                 int     `json:"pr_count"`
+		MergedCount             int     `json:"merged_count"`
+		AverageTimeToMergeHours float64 `json:"average_time_to_merge_hours"`
+	}
+	resp := getJSON(t, ts.URL+"/repos/octo/repo/metrics", &body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if body.PrCount != 6 || body.MergedCount != 5 || body.AverageTimeToMergeHours != 30 {
+		t.Errorf("Unexpected metrics summary: %+v", body)
+	}
+	if resp.Header.Get("ETag") == "" || resp.Header.Get("Last-Modified") == "" {
+		t.Errorf("Expected caching headers, got %v", resp.Header)
+	}
+	if cc := resp.Header.Get("Cache-Control"); cc == "" || cc == "public, max-age=0" {
+		t.Errorf("Expected a positive max-age, got %q", cc)
+	}
+}
+
+func TestEstimateEndpoint(t *testing.T) {
+	ts := newTestServer(t, metrics.EstimateOptions{})
+
+	var body struct {
+		SizeBucket  string `json:"size_bucket"`
+		BasedOn     string `json:"based_on"`
+		TimeToMerge struct {
+			SampleCount int     `json:"sample_count"`
+			MeanHours   float64 `json:"mean_hours"`
+		} `json:"time_to_merge"`
+		ExpectedMergeAt *time.Time `json:"expected_merge_at"`
+	}
+	resp := getJSON(t, ts.URL+"/repos/octo/repo/prs/100/estimate", &body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if body.SizeBucket != "S" || body.BasedOn != "size bucket S" {
+		t.Errorf("Expected an estimate from size bucket S, got %q / %q", body.SizeBucket, body.BasedOn)
+	}
+	if body.TimeToMerge.SampleCount != 5 || body.TimeToMerge.MeanHours != 30 {
+		t.Errorf("Unexpected merge estimate: %+v", body.TimeToMerge)
+	}
+	if body.ExpectedMergeAt == nil {
+		t.Error("Expected an expected_merge_at for an open PR")
+	}
+
+	// Estimate options such as ESTIMATE_EXCLUDE apply to the endpoint
+	excluding := newTestServer(t, metrics.EstimateOptions{Exclude: []int{5}})
+	if getJSON(t, excluding.URL+"/repos/octo/repo/prs/100/estimate", &body); body.TimeToMerge.SampleCount != 4 || body.TimeToMerge.MeanHours != 25 {
+		t.Errorf("Expected PR #5 to be excluded from the merge estimate, got %+v", body.TimeToMerge)
+	}
+
+	if resp := getJSON(t, ts.URL+"/repos/octo/repo/prs/404/estimate", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unknown PR, got %d", resp.StatusCode)
+	}
+	if resp := getJSON(t, ts.URL+"/repos/octo/repo/prs/abc/estimate", nil); resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an invalid PR number, got %d", resp.StatusCode)
+	}
+}
+
+func TestDistributionEndpoint(t *testing.T) {
+	ts := newTestServer(t, metrics.EstimateOptions{})
+
+	var body struct {
+		TimeToFirstReview struct {
+			SampleCount int     `json:"sample_count"`
+			P50Hours    float64 `json:"p50_hours"`
+		} `json:"time_to_first_review"`
+		BySizeBucket map[string]struct {
+			PrCount int `json:"pr_count"`
+		} `json:"by_size_bucket"`
+	}
+	resp := getJSON(t, ts.URL+"/repos/octo/repo/distribution", &body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	if body.TimeToFirstReview.SampleCount != 5 || body.TimeToFirstReview.P50Hours != 3 {
+		t.Errorf("Unexpected first review distribution: %+v", body.TimeToFirstReview)
+	}
+	if body.BySizeBucket["S"].PrCount != 6 {
+		t.Errorf("Expected 6 PRs in size bucket S, got %+v", body.BySizeBucket)
+	}
+}
+
+func TestConditionalRequestAndUnknownRepo(t *testing.T) {
+	ts := newTestServer(t, metrics.EstimateOptions{})
+
+	first := getJSON(t, ts.URL+"/repos/octo/repo/metrics", nil)
+	req, _ := http.NewRequest(http.MethodGet, ts.URL+"/repos/octo/repo/metrics", nil)
+	req.Header.Set("If-None-Match", first.Header.Get("ETag"))
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Conditional GET failed: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusNotModified {
+		t.Errorf("Expected 304 for a matching ETag, got %d", resp.StatusCode)
+	}
+
+	if resp := getJSON(t, ts.URL+"/repos/other/repo/metrics", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("Expected 404 for an unserved repository, got %d", resp.StatusCode)
+	}
+}
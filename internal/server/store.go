@@ -0,0 +1,92 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// Fetcher loads the pull requests of a repository identified as "owner/repo".
+type Fetcher func(ctx context.Context, repo string) ([]*github.PrData, error)
+
+// Snapshot is the most recently fetched state of a single repository.
+type Snapshot struct {
+	Repo        string
+	Prs         []*github.PrData
+	Metrics     []*metrics.PrMetrics
+	RefreshedAt time.Time
+}
+
+// Store keeps an in-memory snapshot per repository and refreshes them in the background.
+type Store struct {
+	fetch Fetcher
+	repos []string
+
+	mu        sync.RWMutex
+	snapshots map[string]*Snapshot
+}
+
+func NewStore(repos []string, fetch Fetcher) *Store {
+	return &Store{
+		fetch:     fetch,
+		repos:     repos,
+		snapshots: make(map[string]*Snapshot),
+	}
+}
+
+// Repos returns the repositories served by the store.
+func (s *Store) Repos() []string {
+	return s.repos
+}
+
+// Snapshot returns the latest snapshot of repo, if it has been fetched successfully at least once.
+func (s *Store) Snapshot(repo string) (*Snapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	snap, ok := s.snapshots[repo]
+	return snap, ok
+}
+
+// Refresh fetches every repository once. A failed fetch keeps the previous snapshot of that repository.
+func (s *Store) Refresh(ctx context.Context) error {
+	var errs []error
+	for _, repo := range s.repos {
+		prs, err := s.fetch(ctx, repo)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("refreshing %s: %w", repo, err))
+			continue
+		}
+
+		snap := &Snapshot{Repo: repo, Prs: prs, RefreshedAt: time.Now()}
+		for _, pr := range prs {
+			snap.Metrics = append(snap.Metrics, metrics.CalculateMetrics(pr))
+		}
+		s.mu.Lock()
+		s.snapshots[repo] = snap
+		s.mu.Unlock()
+		log.Printf("Refreshed %s: %d pull requests", repo, len(prs))
+	}
+	return errors.Join(errs...)
+}
+
+// Run refreshes all repositories every interval until ctx is cancelled.
+func (s *Store) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Printf("Warning: %v", err)
+			}
+		}
+	}
+}
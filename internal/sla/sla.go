@@ -0,0 +1,272 @@
+// Package sla evaluates review SLAs, such as "first review within 1 business day", against PR metrics.
+package sla
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+)
+
+// SLA metrics.
+const (
+	MetricFirstReview = "first_review"
+	MetricMerge       = "merge"
+)
+
+// Statuses of a PR against a rule.
+const (
+	StatusMet      = "met"
+	StatusBreached = "breached"
+	StatusAtRisk   = "at_risk" // Open and most of the allowed time has elapsed
+	StatusPending  = "pending" // Open and within the allowed time
+)
+
+// DefaultAtRisk is the fraction of the allowed time after which open PRs are at risk.
+const DefaultAtRisk = 0.75
+
+// Rule is a single SLA. Repo, Label and SizeBucket restrict which PRs it applies to; empty
+// matches all. The allowed time is either BusinessDays (Monday to Friday) or Hours.
+type Rule struct {
+	Name         string  `json:"name"`
+	Metric       string  `json:"metric"`
+	Repo         string  `json:"repo,omitempty"`
+	Label        string  `json:"label,omitempty"`
+	SizeBucket   string  `json:"size_bucket,omitempty"`
+	BusinessDays float64 `json:"business_days,omitempty"`
+	Hours        float64 `json:"hours,omitempty"`
+}
+
+// Config is the SLA rules file.
+type Config struct {
+	// Timezone in which business days are counted, e.g. "Europe/Berlin". Defaults to UTC.
+	Timezone string  `json:"timezone,omitempty"`
+	AtRisk   float64 `json:"at_risk,omitempty"`
+	Rules    []Rule  `json:"rules"`
+
+	location *time.Location
+}
+
+// Load reads and validates a JSON rules file.
+func Load(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := cfg.validate(); err != nil {
+		return nil, fmt.Errorf("invalid SLA rules in %s: %w", path, err)
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	c.location = time.UTC
+	if c.Timezone != "" {
+		loc, err := time.LoadLocation(c.Timezone)
+		if err != nil {
+			return err
+		}
+		c.location = loc
+	}
+	if c.AtRisk == 0 {
+		c.AtRisk = DefaultAtRisk
+	}
+	if c.AtRisk < 0 || c.AtRisk > 1 {
+		return fmt.Errorf("at_risk must be between 0 and 1, got %v", c.AtRisk)
+	}
+	if len(c.Rules) == 0 {
+		return fmt.Errorf("no rules")
+	}
+	for i, r := range c.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rule %d has no name", i+1)
+		}
+		if r.Metric != MetricFirstReview && r.Metric != MetricMerge {
+			return fmt.Errorf("rule %q: unknown metric %q (expected %s or %s)", r.Name, r.Metric, MetricFirstReview, MetricMerge)
+		}
+		if (r.BusinessDays > 0) == (r.Hours > 0) {
+			return fmt.Errorf("rule %q: set exactly one of business_days and hours", r.Name)
+		}
+	}
+	return nil
+}
+
+// Deadline returns when a PR created at created has to meet rule.
+func (c *Config) Deadline(rule Rule, created time.Time) time.Time {
+	if rule.Hours > 0 {
+		return created.Add(time.Duration(rule.Hours * float64(time.Hour)))
+	}
+	return AddBusinessDays(created, rule.BusinessDays, c.location)
+}
+
+// AddBusinessDays adds days of weekday time to t, skipping Saturdays and Sundays in loc.
+// Time on a weekend starts counting on the following Monday.
+func AddBusinessDays(t time.Time, days float64, loc *time.Location) time.Time {
+	t = t.In(loc)
+	remaining := time.Duration(days * 24 * float64(time.Hour))
+	for {
+		midnight := time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		if t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			left := midnight.Sub(t)
+			if remaining <= left {
+				return t.Add(remaining)
+			}
+			remaining -= left
+		}
+		t = midnight
+	}
+}
+
+// Result is the status of a single PR against a rule.
+type Result struct {
+	Pr          *metrics.PrMetrics
+	Status      string
+	Deadline    time.Time
+	CompletedAt *time.Time // When the PR was reviewed or merged, if it was
+}
+
+// PeriodCompliance is the compliance of the PRs created in one period.
+type PeriodCompliance struct {
+	Start      time.Time
+	Met        int
+	Breached   int
+	Compliance float64 // Fraction of met PRs among met and breached PRs
+}
+
+// RuleReport is the evaluation of one rule.
+type RuleReport struct {
+	Rule       Rule
+	Met        int
+	Breached   int
+	Compliance float64
+	Periods    []PeriodCompliance
+	Breaches   []Result // Breaching PRs, most recent deadline first
+	AtRisk     []Result // Open PRs at risk of breaching, nearest deadline first
+}
+
+// Evaluate applies every rule matching repo to history at time now, with compliance per period
+// (metrics.PeriodWeek or metrics.PeriodMonth) of PR creation. Open PRs past their deadline count
+// as breaches; PRs closed without being reviewed or merged are skipped.
+func (c *Config) Evaluate(repo string, history []*metrics.PrMetrics, period string, now time.Time) []RuleReport {
+	var reports []RuleReport
+	for _, rule := range c.Rules {
+		if rule.Repo != "" && rule.Repo != repo {
+			continue
+		}
+
+		report := RuleReport{Rule: rule}
+		periods := make(map[time.Time]*PeriodCompliance)
+		for _, m := range history {
+			if !matches(rule, m) {
+				continue
+			}
+			result, ok := c.evaluate(rule, m, now)
+			if !ok {
+				continue
+			}
+
+			switch result.Status {
+			case StatusAtRisk:
+				report.AtRisk = append(report.AtRisk, result)
+				continue
+			case StatusPending:
+				continue
+			}
+			start := metrics.PeriodStart(m.CreatedAt, period)
+			p := periods[start]
+			if p == nil {
+				p = &PeriodCompliance{Start: start}
+				periods[start] = p
+			}
+			if result.Status == StatusMet {
+				report.Met++
+				p.Met++
+			} else {
+				report.Breached++
+				p.Breached++
+				report.Breaches = append(report.Breaches, result)
+			}
+		}
+
+		report.Compliance = compliance(report.Met, report.Breached)
+		for _, p := range periods {
+			p.Compliance = compliance(p.Met, p.Breached)
+			report.Periods = append(report.Periods, *p)
+		}
+		sort.Slice(report.Periods, func(i, j int) bool { return report.Periods[i].Start.Before(report.Periods[j].Start) })
+		sort.Slice(report.Breaches, func(i, j int) bool { return report.Breaches[i].Deadline.After(report.Breaches[j].Deadline) })
+		sort.Slice(report.AtRisk, func(i, j int) bool { return report.AtRisk[i].Deadline.Before(report.AtRisk[j].Deadline) })
+		reports = append(reports, report)
+	}
+	return reports
+}
+
+// OpenBreaches returns the breaches of PRs still waiting for the review or merge the rule
+// measures, the ones that can still be acted on, as opposed to breaches in the history.
+func (r RuleReport) OpenBreaches() []Result {
+	var open []Result
+	for _, b := range r.Breaches {
+		if b.CompletedAt == nil {
+			open = append(open, b)
+		}
+	}
+	return open
+}
+
+// evaluate returns false for PRs the rule can't be judged on.
+func (c *Config) evaluate(rule Rule, m *metrics.PrMetrics, now time.Time) (Result, bool) {
+	result := Result{Pr: m, Deadline: c.Deadline(rule, m.CreatedAt)}
+
+	d := metrics.FirstReviewSelector(m)
+	if rule.Metric == MetricMerge {
+		d = metrics.MergeSelector(m)
+	}
+	if d > 0 {
+		completed := m.CreatedAt.Add(d)
+		result.CompletedAt = &completed
+		result.Status = StatusMet
+		if completed.After(result.Deadline) {
+			result.Status = StatusBreached
+		}
+		return result, true
+	}
+	if m.State != "open" {
+		return result, false
+	}
+
+	allowed := result.Deadline.Sub(m.CreatedAt)
+	switch elapsed := now.Sub(m.CreatedAt); {
+	case now.After(result.Deadline):
+		result.Status = StatusBreached
+	case float64(elapsed) >= c.AtRisk*float64(allowed):
+		result.Status = StatusAtRisk
+	default:
+		result.Status = StatusPending
+	}
+	return result, true
+}
+
+func matches(rule Rule, m *metrics.PrMetrics) bool {
+	if rule.Label != "" && !slices.Contains(m.Labels, rule.Label) {
+		return false
+	}
+	if rule.SizeBucket != "" && rule.SizeBucket != metrics.SizeBucket(m.Additions, m.Deletions) {
+		return false
+	}
+	return true
+}
+
+func compliance(met, breached int) float64 {
+	if met+breached == 0 {
+		return 0
+	}
+	return float64(met) / float64(met+breached)
+}
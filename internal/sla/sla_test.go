@@ -0,0 +1,99 @@
+package sla_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/sla"
+)
+
+func TestAddBusinessDays(t *testing.T) {
+	cases := []struct {
+		start    string
+		days     float64
+		expected string
+	}{
+		{"2025-03-04T10:00:00Z", 1, "2025-03-05T10:00:00Z"},   // Tuesday to Wednesday
+		{"2025-03-07T10:00:00Z", 1, "2025-03-10T10:00:00Z"},   // Friday to Monday
+		{"2025-03-08T10:00:00Z", 1, "2025-03-11T00:00:00Z"},   // Saturday counts from Monday morning
+		{"2025-03-07T18:00:00Z", 0.5, "2025-03-10T06:00:00Z"}, // Half a day spanning the weekend
+		{"2025-03-03T09:00:00Z", 5, "2025-03-10T09:00:00Z"},   // A full working week
+	}
+	for _, c := range cases {
+		start, _ := time.Parse(time.RFC3339, c.start)
+		expected, _ := time.Parse(time.RFC3339, c.expected)
+		if got := sla.AddBusinessDays(start, c.days, time.UTC); !got.Equal(expected) {
+			t.Errorf("AddBusinessDays(%s, %v) = %s, expected %s", c.start, c.days, got.Format(time.RFC3339), c.expected)
+		}
+	}
+}
+
+func TestLoadRejectsInvalidRules(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"no-target.json":   `{"rules": [{"name": "x", "metric": "merge"}]}`,
+		"two-targets.json": `{"rules": [{"name": "x", "metric": "merge", "hours": 1, "business_days": 1}]}`,
+		"bad-metric.json":  `{"rules": [{"name": "x", "metric": "close", "hours": 1}]}`,
+		"bad-tz.json":      `{"timezone": "Mars/Olympus", "rules": [{"name": "x", "metric": "merge", "hours": 1}]}`,
+	} {
+		path := filepath.Join(dir, name)
+		os.WriteFile(path, []byte(content), 0o644)
+		if _, err := sla.Load(path); err == nil {
+			t.Errorf("Expected %s to be rejected", name)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	cfg, err := sla.Load("testdata/rules.json")
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+
+	monday := time.Date(2025, 3, 3, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 3, 14, 12, 0, 0, 0, time.UTC) // Friday of the second week
+	history := []*metrics.PrMetrics{
+		// Week 1: reviewed in time, reviewed late (Friday PR reviewed Tuesday)
+		{Number: 1, State: "closed", CreatedAt: monday, TimeToFirstReview: 5 * time.Hour, Merged: true, TimeToMerge: 20 * time.Hour, Labels: []string{"bug"}},
+		{Number: 2, State: "closed", CreatedAt: monday.AddDate(0, 0, 4), TimeToFirstReview: 96 * time.Hour, Merged: true, TimeToMerge: 100 * time.Hour, Labels: []string{"bug"}},
+		// Closed without review: skipped
+		{Number: 3, State: "closed", CreatedAt: monday},
+		// Open PRs: past the deadline, at risk, and fresh
+		{Number: 4, State: "open", CreatedAt: now.Add(-48 * time.Hour)},
+		{Number: 5, State: "open", CreatedAt: now.Add(-20 * time.Hour)},
+		{Number: 6, State: "open", CreatedAt: now.Add(-time.Hour)},
+	}
+
+	reports := cfg.Evaluate("octo/repo", history, metrics.PeriodWeek, now)
+	if len(reports) != 2 {
+		t.Fatalf("Expected the rule for another repository to be skipped, got %d reports", len(reports))
+	}
+
+	review := reports[0]
+	if review.Met != 1 || review.Breached != 2 || review.Compliance != 1.0/3 {
+		t.Errorf("Unexpected first review compliance: %d met, %d breached, %v", review.Met, review.Breached, review.Compliance)
+	}
+	if len(review.Periods) != 2 || review.Periods[0].Met != 1 || review.Periods[0].Breached != 1 || review.Periods[1].Breached != 1 {
+		t.Errorf("Unexpected periods: %+v", review.Periods)
+	}
+	if len(review.Breaches) != 2 || review.Breaches[0].Pr.Number != 4 || review.Breaches[1].Pr.Number != 2 {
+		t.Errorf("Expected breaches #4 and #2, got %+v", review.Breaches)
+	}
+	if len(review.AtRisk) != 1 || review.AtRisk[0].Pr.Number != 5 {
+		t.Errorf("Expected #5 at risk, got %+v", review.AtRisk)
+	}
+	if open := review.OpenBreaches(); len(open) != 1 || open[0].Pr.Number != 4 {
+		t.Errorf("Expected #4 as the only open breach, got %+v", open)
+	}
+
+	bugs := reports[1]
+	if bugs.Met != 1 || bugs.Breached != 1 || bugs.Breaches[0].Pr.Number != 2 {
+		t.Errorf("Unexpected bug merge compliance: %+v", bugs)
+	}
+	if open := bugs.OpenBreaches(); len(open) != 0 {
+		t.Errorf("Expected the merged #2 not to be an open breach, got %+v", open)
+	}
+}
@@ -0,0 +1,110 @@
+// Package webhook receives GitHub pull_request webhooks and keeps a sticky estimate comment on each PR.
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/report"
+)
+
+// maxPayloadBytes matches the 25 MB cap GitHub puts on webhook payloads.
+const maxPayloadBytes = 25 << 20
+
+// Commenter creates or updates the sticky estimate comment on a PR.
+type Commenter interface {
+	UpsertComment(ctx context.Context, number int, marker, body string) error
+}
+
+// History returns the cached historical metrics of a repository ("owner/repo"), if available.
+type History func(repo string) ([]*metrics.PrMetrics, bool)
+
+type Handler struct {
+	secret    []byte
+	history   History
+	commenter func(repo string) Commenter
+	opts      metrics.EstimateOptions
+}
+
+// NewHandler comments estimates made with opts on the PRs of verified webhook deliveries.
+func NewHandler(secret string, history History, commenter func(repo string) Commenter, opts metrics.EstimateOptions) *Handler {
+	return &Handler{
+		secret:    []byte(secret),
+		history:   history,
+		commenter: commenter,
+		opts:      opts,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "could not read payload", http.StatusBadRequest)
+		return
+	}
+	if !h.validSignature(payload, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	if r.Header.Get("X-GitHub-Event") != "pull_request" {
+		w.WriteHeader(http.StatusNoContent) // e.g. the initial "ping"
+		return
+	}
+	event, err := github.ParsePullRequestEvent(payload)
+	if err != nil {
+		http.Error(w, "invalid pull_request payload", http.StatusBadRequest)
+		return
+	}
+	switch event.Action {
+	case "opened", "synchronize", "ready_for_review":
+	default:
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if event.PullRequest.Draft {
+		w.WriteHeader(http.StatusNoContent) // Drafts get their comment on ready_for_review
+		return
+	}
+
+	repo := event.Repository.FullName
+	history, ok := h.history(repo)
+	if !ok {
+		log.Printf("Warning: No cached history for %s, skipping estimate for PR #%d", repo, event.PullRequest.Number)
+		w.WriteHeader(http.StatusAccepted)
+		return
+	}
+
+	pr := metrics.CalculateMetrics(event.PrData())
+	body := report.EstimateMarkdown(pr, metrics.EstimateForPrWithOptions(history, pr, h.opts))
+	if err := h.commenter(repo).UpsertComment(r.Context(), pr.Number, report.EstimateMarker, body); err != nil {
+		log.Printf("Error commenting estimate on %s#%d: %v", repo, pr.Number, err)
+		http.Error(w, "could not comment estimate", http.StatusBadGateway)
+		return
+	}
+	log.Printf("Commented estimate on %s#%d (%s)", repo, pr.Number, event.Action)
+	w.WriteHeader(http.StatusOK)
+}
+
+// validSignature checks the "sha256=<hex HMAC>" signature GitHub computes over the raw payload.
+func (h *Handler) validSignature(payload []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, h.secret)
+	mac.Write(payload)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
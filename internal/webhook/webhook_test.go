@@ -0,0 +1,157 @@
+package webhook_test
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sushant-115/pr-effort-estimator/api/github"
+	"github.com/sushant-115/pr-effort-estimator/internal/metrics"
+	"github.com/sushant-115/pr-effort-estimator/internal/webhook"
+	"github.com/sushant-115/pr-effort-estimator/pkg/config"
+)
+
+const secret = "It's a Secret to Everybody"
+
+// mockGitHubAPI records comment writes; existing, when set, is returned as a prior comment on PR 7.
+type mockGitHubAPI struct {
+	mu       sync.Mutex
+	created  []string
+	edited   map[string]string
+	existing string
+}
+
+func (m *mockGitHubAPI) server(t *testing.T) *httptest.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/octo/repo/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		comments := []map[string]any{{"id": 1, "body": "Nice work!"}}
+		if m.existing != "" {
+			comments = append(comments, map[string]any{"id": 42, "body": m.existing})
+		}
+		json.NewEncoder(w).Encode(comments)
+	})
+	mux.HandleFunc("POST /repos/octo/repo/issues/7/comments", func(w http.ResponseWriter, r *http.Request) {
+		var comment struct{ Body string }
+		json.NewDecoder(r.Body).Decode(&comment)
+		m.mu.Lock()
+		m.created = append(m.created, comment.Body)
+		m.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{"id": 43, "body": comment.Body})
+	})
+	mux.HandleFunc("PATCH /repos/octo/repo/issues/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var comment struct{ Body string }
+		json.NewDecoder(r.Body).Decode(&comment)
+		m.mu.Lock()
+		m.edited[r.PathValue("id")] = comment.Body
+		m.mu.Unlock()
+		json.NewEncoder(w).Encode(map[string]any{"id": 42, "body": comment.Body})
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newHandler(t *testing.T, api *mockGitHubAPI) *webhook.Handler {
+	apiServer := api.server(t)
+	var history []*metrics.PrMetrics
+	for i := 1; i <= 6; i++ {
+		history = append(history, &metrics.PrMetrics{
+			Number: i, Additions: 30, Merged: true,
+			TimeToFirstReview: time.Duration(i) * time.Hour,
+			TimeToMerge:       time.Duration(10*i) * time.Hour,
+		})
+	}
+
+	return webhook.NewHandler(secret,
+		func(repo string) ([]*metrics.PrMetrics, bool) {
+			return history, repo == "octo/repo"
+		},
+		func(repo string) webhook.Commenter {
+			owner, name, _ := strings.Cut(repo, "/")
+			return github.NewClient(&config.GitHubConfig{Token: "dummy_token", Owner: owner, Repo: name, BaseURL: apiServer.URL})
+		},
+		metrics.EstimateOptions{},
+	)
+}
+
+func deliver(t *testing.T, handler http.Handler, event, fixture string, sign bool) *httptest.ResponseRecorder {
+	payload, err := os.ReadFile(fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/webhook", bytes.NewReader(payload))
+	req.Header.Set("X-GitHub-Event", event)
+	if sign {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(payload)
+		req.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestOpenedCreatesComment(t *testing.T) {
+	api := &mockGitHubAPI{edited: map[string]string{}}
+	rec := deliver(t, newHandler(t, api), "pull_request", "testdata/pull_request_opened.json", true)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(api.created) != 1 || len(api.edited) != 0 {
+		t.Fatalf("Expected exactly one new comment, got created=%d edited=%d", len(api.created), len(api.edited))
+	}
+	if !strings.Contains(api.created[0], "Based on size bucket S") || !strings.Contains(api.created[0], "| Time to merge |") {
+		t.Errorf("Unexpected comment body:\n%s", api.created[0])
+	}
+}
+
+func TestOpenedUpdatesStickyComment(t *testing.T) {
+	api := &mockGitHubAPI{edited: map[string]string{}, existing: "<!-- pr-effort-estimator -->\nold estimate"}
+	rec := deliver(t, newHandler(t, api), "pull_request", "testdata/pull_request_opened.json", true)
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", rec.Code, rec.Body)
+	}
+	if len(api.created) != 0 || api.edited["42"] == "" {
+		t.Errorf("Expected the existing comment 42 to be edited, got created=%d edited=%v", len(api.created), api.edited)
+	}
+}
+
+func TestRejectsInvalidSignature(t *testing.T) {
+	api := &mockGitHubAPI{edited: map[string]string{}}
+	rec := deliver(t, newHandler(t, api), "pull_request", "testdata/pull_request_opened.json", false)
+
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("Expected 401 for an unsigned delivery, got %d", rec.Code)
+	}
+	if len(api.created) != 0 {
+		t.Errorf("Expected no comment for an unsigned delivery")
+	}
+}
+
+func TestIgnoresDraftsAndOtherEvents(t *testing.T) {
+	api := &mockGitHubAPI{edited: map[string]string{}}
+	handler := newHandler(t, api)
+
+	if rec := deliver(t, handler, "pull_request", "testdata/pull_request_synchronize_draft.json", true); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 for a draft PR, got %d", rec.Code)
+	}
+	if rec := deliver(t, handler, "ping", "testdata/pull_request_opened.json", true); rec.Code != http.StatusNoContent {
+		t.Errorf("Expected 204 for a ping event, got %d", rec.Code)
+	}
+	if len(api.created) != 0 || len(api.edited) != 0 {
+		t.Errorf("Expected no comments, got created=%d edited=%d", len(api.created), len(api.edited))
+	}
+}
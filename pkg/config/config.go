@@ -3,13 +3,19 @@ package config
 import (
 	"fmt"
 	"os"
+	"path"
+	"strconv"
+	"strings"
+	"time"
 )
 
 type GitHubConfig struct {
-	Token      string
-	Owner      string
-	Repo       string
-	BaseBranch string // Optional: for filtering PRs
+	Token         string
+	Owner         string
+	Repo          string
+	BaseBranch    string // Optional: for filtering PRs
+	BaseURL       string // Optional: REST API root for GitHub Enterprise, e.g. "https://ghe.example.com/api/v3"
+	WebhookSecret string // Optional: secret used to verify webhook deliveries
 }
 
 func LoadGitHubConfig() (*GitHubConfig, error) {
@@ -29,8 +35,331 @@ func LoadGitHubConfig() (*GitHubConfig, error) {
 	}
 
 	return &GitHubConfig{
-		Token: token,
-		Owner: owner,
-		Repo:  repo,
+		Token:         token,
+		Owner:         owner,
+		Repo:          repo,
+		BaseURL:       os.Getenv("GITHUB_API_URL"),
+		WebhookSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
 	}, nil
 }
+
+type GitLabConfig struct {
+	Token   string
+	BaseURL string // e.g., "https://gitlab.com" or a self-managed instance
+	Project string // Numeric project ID or full path, e.g. "group/project"
+}
+
+func LoadGitLabConfig() (*GitLabConfig, error) {
+	token := os.Getenv("GITLAB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITLAB_TOKEN environment variable not set")
+	}
+
+	project := os.Getenv("GITLAB_PROJECT")
+	if project == "" {
+		return nil, fmt.Errorf("GITLAB_PROJECT environment variable not set")
+	}
+
+	baseURL := os.Getenv("GITLAB_URL")
+	if baseURL == "" {
+		baseURL = "https://gitlab.com"
+	}
+
+	return &GitLabConfig{
+		Token:   token,
+		BaseURL: baseURL,
+		Project: project,
+	}, nil
+}
+
+type BitbucketConfig struct {
+	Token     string // Repository/workspace access token, or an app password when Username is set
+	Username  string // Optional: enables basic auth with an app password
+	BaseURL   string // e.g., "https://api.bitbucket.org/2.0"
+	Workspace string
+	Repo      string
+}
+
+func LoadBitbucketConfig() (*BitbucketConfig, error) {
+	token := os.Getenv("BITBUCKET_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("BITBUCKET_TOKEN environment variable not set")
+	}
+
+	workspace := os.Getenv("BITBUCKET_WORKSPACE")
+	if workspace == "" {
+		return nil, fmt.Errorf("BITBUCKET_WORKSPACE environment variable not set")
+	}
+
+	repo := os.Getenv("BITBUCKET_REPO")
+	if repo == "" {
+		return nil, fmt.Errorf("BITBUCKET_REPO environment variable not set")
+	}
+
+	baseURL := os.Getenv("BITBUCKET_URL")
+	if baseURL == "" {
+		baseURL = "https://api.bitbucket.org/2.0"
+	}
+
+	return &BitbucketConfig{
+		Token:     token,
+		Username:  os.Getenv("BITBUCKET_USERNAME"),
+		BaseURL:   baseURL,
+		Workspace: workspace,
+		Repo:      repo,
+	}, nil
+}
+
+type AzureDevOpsConfig struct {
+	Token   string // Personal access token with Code (Read) scope
+	OrgURL  string // e.g., "https://dev.azure.com/my-org"
+	Project string
+	Repo    string
+}
+
+func LoadAzureDevOpsConfig() (*AzureDevOpsConfig, error) {
+	token := os.Getenv("AZURE_DEVOPS_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("AZURE_DEVOPS_TOKEN environment variable not set")
+	}
+
+	orgURL := os.Getenv("AZURE_DEVOPS_ORG_URL")
+	if orgURL == "" {
+		return nil, fmt.Errorf("AZURE_DEVOPS_ORG_URL environment variable not set")
+	}
+
+	project := os.Getenv("AZURE_DEVOPS_PROJECT")
+	if project == "" {
+		return nil, fmt.Errorf("AZURE_DEVOPS_PROJECT environment variable not set")
+	}
+
+	repo := os.Getenv("AZURE_DEVOPS_REPO")
+	if repo == "" {
+		return nil, fmt.Errorf("AZURE_DEVOPS_REPO environment variable not set")
+	}
+
+	return &AzureDevOpsConfig{
+		Token:   token,
+		OrgURL:  orgURL,
+		Project: project,
+		Repo:    repo,
+	}, nil
+}
+
+type GerritConfig struct {
+	BaseURL  string // e.g., "https://gerrit-review.googlesource.com"
+	Project  string
+	Username string // Optional: HTTP credentials for authenticated (/a/) endpoints
+	Password string
+}
+
+func LoadGerritConfig() (*GerritConfig, error) {
+	baseURL := os.Getenv("GERRIT_URL")
+	if baseURL == "" {
+		return nil, fmt.Errorf("GERRIT_URL environment variable not set")
+	}
+
+	project := os.Getenv("GERRIT_PROJECT")
+	if project == "" {
+		return nil, fmt.Errorf("GERRIT_PROJECT environment variable not set")
+	}
+
+	return &GerritConfig{
+		BaseURL:  baseURL,
+		Project:  project,
+		Username: os.Getenv("GERRIT_USERNAME"),
+		Password: os.Getenv("GERRIT_PASSWORD"),
+	}, nil
+}
+
+type GitLocalConfig struct {
+	Path   string // Path to a local clone or mirror
+	Branch string // Branch whose first-parent history is analyzed, e.g. "main"
+}
+
+func LoadGitLocalConfig() (*GitLocalConfig, error) {
+	path := os.Getenv("GIT_REPO_PATH")
+	if path == "" {
+		return nil, fmt.Errorf("GIT_REPO_PATH environment variable not set")
+	}
+
+	branch := os.Getenv("GIT_BRANCH")
+	if branch == "" {
+		branch = "HEAD"
+	}
+
+	return &GitLocalConfig{
+		Path:   path,
+		Branch: branch,
+	}, nil
+}
+
+// ActionsConfig holds the environment GitHub Actions provides to a workflow step.
+type ActionsConfig struct {
+	GitHub      *GitHubConfig
+	EventPath   string // JSON payload of the triggering event
+	StepSummary string // Optional: file whose Markdown is shown on the run's summary page
+	Output      string // Optional: file collecting step outputs
+}
+
+func LoadActionsConfig() (*ActionsConfig, error) {
+	token := os.Getenv("GITHUB_TOKEN")
+	if token == "" {
+		return nil, fmt.Errorf("GITHUB_TOKEN environment variable not set")
+	}
+
+	repository := os.Getenv("GITHUB_REPOSITORY")
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" {
+		return nil, fmt.Errorf("GITHUB_REPOSITORY environment variable not set or not in owner/repo form")
+	}
+
+	eventPath := os.Getenv("GITHUB_EVENT_PATH")
+	if eventPath == "" {
+		return nil, fmt.Errorf("GITHUB_EVENT_PATH environment variable not set")
+	}
+
+	return &ActionsConfig{
+		GitHub: &GitHubConfig{
+			Token:   token,
+			Owner:   owner,
+			Repo:    repo,
+			BaseURL: os.Getenv("GITHUB_API_URL"),
+		},
+		EventPath:   eventPath,
+		StepSummary: os.Getenv("GITHUB_STEP_SUMMARY"),
+		Output:      os.Getenv("GITHUB_OUTPUT"),
+	}, nil
+}
+
+// NotifyConfig holds the webhooks digests are posted to. At least one must be set.
+type NotifyConfig struct {
+	SlackWebhookURL string // Slack incoming webhook
+	TeamsWebhookURL string // Microsoft Teams incoming webhook
+	WebhookURL      string // Any endpoint accepting a JSON POST
+}
+
+func LoadNotifyConfig() (*NotifyConfig, error) {
+	cfg := &NotifyConfig{
+		SlackWebhookURL: os.Getenv("NOTIFY_SLACK_WEBHOOK_URL"),
+		TeamsWebhookURL: os.Getenv("NOTIFY_TEAMS_WEBHOOK_URL"),
+		WebhookURL:      os.Getenv("NOTIFY_WEBHOOK_URL"),
+	}
+	if cfg.SlackWebhookURL == "" && cfg.TeamsWebhookURL == "" && cfg.WebhookURL == "" {
+		return nil, fmt.Errorf("none of NOTIFY_SLACK_WEBHOOK_URL, NOTIFY_TEAMS_WEBHOOK_URL or NOTIFY_WEBHOOK_URL environment variables set")
+	}
+	return cfg, nil
+}
+
+// FeaturesConfig controls how features are derived from the changed files of pull requests.
+type FeaturesConfig struct {
+	ExcludeGenerated  bool     // Leave generated, vendored and lock files out of PR sizes
+	GeneratedPatterns []string // Optional: extra path.Match patterns for generated files, matched against paths and file names
+	GoComplexity      bool     // Parse the base and head versions of changed .go files, fetching each from the code host
+}
+
+func LoadFeaturesConfig() (*FeaturesConfig, error) {
+	cfg := &FeaturesConfig{}
+	for name, dst := range map[string]*bool{"EXCLUDE_GENERATED_FROM_SIZE": &cfg.ExcludeGenerated, "GO_COMPLEXITY": &cfg.GoComplexity} {
+		if v := os.Getenv(name); v != "" {
+			enabled, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, fmt.Errorf("invalid %s %q: %v", name, v, err)
+			}
+			*dst = enabled
+		}
+	}
+	for _, pattern := range strings.Split(os.Getenv("GENERATED_PATTERNS"), ",") {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			if _, err := path.Match(pattern, ""); err != nil {
+				return nil, fmt.Errorf("invalid pattern %q in GENERATED_PATTERNS: %v", pattern, err)
+			}
+			cfg.GeneratedPatterns = append(cfg.GeneratedPatterns, pattern)
+		}
+	}
+	return cfg, nil
+}
+
+// EstimateConfig weights historical PRs by recency and limits the influence of outliers in
+// estimates. The zero value weights all PRs equally and keeps every observation.
+type EstimateConfig struct {
+	HalfLife        time.Duration // Optional: age at which a PR counts half as much as a new one
+	Window          time.Duration // Optional: ignore PRs opened longer ago than this
+	Outliers        string        // Optional: trim, winsorize or mad
+	LowerPercentile float64       // Percentiles to trim or winsorize at, 0 for the defaults
+	UpperPercentile float64
+	Exclude         []int // Optional: PR numbers left out of estimates
+}
+
+func LoadEstimateConfig() (*EstimateConfig, error) {
+	cfg := &EstimateConfig{}
+	for name, dst := range map[string]*time.Duration{"ESTIMATE_HALF_LIFE": &cfg.HalfLife, "ESTIMATE_WINDOW": &cfg.Window} {
+		if v := os.Getenv(name); v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil || d < 0 {
+				return nil, fmt.Errorf("invalid %s %q: expected a positive duration such as 2160h", name, v)
+			}
+			*dst = d
+		}
+	}
+	var err error
+	if cfg.Outliers, err = ParseOutliers(os.Getenv("ESTIMATE_OUTLIERS")); err != nil {
+		return nil, fmt.Errorf("invalid ESTIMATE_OUTLIERS: %v", err)
+	}
+	if v := os.Getenv("ESTIMATE_OUTLIER_PERCENTILES"); v != "" {
+		if cfg.LowerPercentile, cfg.UpperPercentile, err = ParsePercentiles(v); err != nil {
+			return nil, fmt.Errorf("invalid ESTIMATE_OUTLIER_PERCENTILES: %v", err)
+		}
+	}
+	if cfg.Exclude, err = ParseNumbers(os.Getenv("ESTIMATE_EXCLUDE")); err != nil {
+		return nil, fmt.Errorf("invalid ESTIMATE_EXCLUDE: %v", err)
+	}
+	return cfg, nil
+}
+
+// ParseOutliers validates an outlier handling method; the empty string and "none" disable it.
+func ParseOutliers(method string) (string, error) {
+	switch method {
+	case "", "none":
+		return "", nil
+	case "trim", "winsorize", "mad":
+		return method, nil
+	}
+	return "", fmt.Errorf("unknown outlier handling %q (expected none, trim, winsorize or mad)", method)
+}
+
+// ParsePercentiles parses a "lower,upper" pair of percentiles such as "5,95".
+func ParsePercentiles(s string) (float64, float64, error) {
+	lowerText, upperText, ok := strings.Cut(s, ",")
+	if !ok {
+		return 0, 0, fmt.Errorf("expected two comma-separated percentiles, got %q", s)
+	}
+	lower, err := strconv.ParseFloat(strings.TrimSpace(lowerText), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	upper, err := strconv.ParseFloat(strings.TrimSpace(upperText), 64)
+	if err != nil {
+		return 0, 0, err
+	}
+	if lower < 0 || upper > 100 || lower >= upper {
+		return 0, 0, fmt.Errorf("percentiles %q must satisfy 0 <= lower < upper <= 100", s)
+	}
+	return lower, upper, nil
+}
+
+// ParseNumbers parses a comma-separated list of PR numbers, with or without a leading "#".
+func ParseNumbers(s string) ([]int, error) {
+	var numbers []int
+	for _, n := range strings.Split(s, ",") {
+		if n = strings.TrimSpace(n); n == "" {
+			continue
+		}
+		number, err := strconv.Atoi(strings.TrimPrefix(n, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PR number %q", n)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
@@ -3,6 +3,7 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/sushant-115/pr-effort-estimator/pkg/config"
 )
@@ -95,3 +96,204 @@ func TestLoadGitHubConfig_MissingRepo(t *testing.T) {
 		t.Errorf("Expected error '%s', got '%s'", expectedErr, err.Error())
 	}
 }
+
+func TestLoadGitLabConfig_Defaults(t *testing.T) {
+	os.Setenv("GITLAB_TOKEN", "test_token")
+	os.Setenv("GITLAB_PROJECT", "group/project")
+	os.Unsetenv("GITLAB_URL")
+	defer func() {
+		os.Unsetenv("GITLAB_TOKEN")
+		os.Unsetenv("GITLAB_PROJECT")
+	}()
+
+	cfg, err := config.LoadGitLabConfig()
+	if err != nil {
+		t.Fatalf("LoadGitLabConfig failed unexpectedly: %v", err)
+	}
+
+	if cfg.Project != "group/project" {
+		t.Errorf("Expected project 'group/project', got '%s'", cfg.Project)
+	}
+	if cfg.BaseURL != "https://gitlab.com" {
+		t.Errorf("Expected default base URL 'https://gitlab.com', got '%s'", cfg.BaseURL)
+	}
+}
+
+func TestLoadGitLabConfig_MissingProject(t *testing.T) {
+	os.Setenv("GITLAB_TOKEN", "test_token")
+	os.Unsetenv("GITLAB_PROJECT")
+	defer os.Unsetenv("GITLAB_TOKEN")
+
+	_, err := config.LoadGitLabConfig()
+	if err == nil {
+		t.Fatal("Expected an error when GITLAB_PROJECT is missing, but got none")
+	}
+	expectedErr := "GITLAB_PROJECT environment variable not set"
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error '%s', got '%s'", expectedErr, err.Error())
+	}
+}
+
+func TestLoadBitbucketConfig_Defaults(t *testing.T) {
+	os.Setenv("BITBUCKET_TOKEN", "test_token")
+	os.Setenv("BITBUCKET_WORKSPACE", "test_workspace")
+	os.Setenv("BITBUCKET_REPO", "test_repo")
+	defer func() {
+		os.Unsetenv("BITBUCKET_TOKEN")
+		os.Unsetenv("BITBUCKET_WORKSPACE")
+		os.Unsetenv("BITBUCKET_REPO")
+	}()
+
+	cfg, err := config.LoadBitbucketConfig()
+	if err != nil {
+		t.Fatalf("LoadBitbucketConfig failed unexpectedly: %v", err)
+	}
+	if cfg.BaseURL != "https://api.bitbucket.org/2.0" {
+		t.Errorf("Expected default base URL 'https://api.bitbucket.org/2.0', got '%s'", cfg.BaseURL)
+	}
+	if cfg.Username != "" {
+		t.Errorf("Expected empty username, got '%s'", cfg.Username)
+	}
+}
+
+func TestLoadAzureDevOpsConfig_MissingOrgURL(t *testing.T) {
+	os.Setenv("AZURE_DEVOPS_TOKEN", "test_token")
+	os.Unsetenv("AZURE_DEVOPS_ORG_URL")
+	defer os.Unsetenv("AZURE_DEVOPS_TOKEN")
+
+	_, err := config.LoadAzureDevOpsConfig()
+	if err == nil {
+		t.Fatal("Expected an error when AZURE_DEVOPS_ORG_URL is missing, but got none")
+	}
+	expectedErr := "AZURE_DEVOPS_ORG_URL environment variable not set"
+	if err.Error() != expectedErr {
+		t.Errorf("Expected error '%s', got '%s'", expectedErr, err.Error())
+	}
+}
+
+func TestLoadGerritConfig_AnonymousAccess(t *testing.T) {
+	os.Setenv("GERRIT_URL", "https://review.example.com")
+	os.Setenv("GERRIT_PROJECT", "tools/estimator")
+	os.Unsetenv("GERRIT_USERNAME")
+	defer func() {
+		os.Unsetenv("GERRIT_URL")
+		os.Unsetenv("GERRIT_PROJECT")
+	}()
+
+	cfg, err := config.LoadGerritConfig()
+	if err != nil {
+		t.Fatalf("LoadGerritConfig failed unexpectedly: %v", err)
+	}
+	if cfg.Project != "tools/estimator" || cfg.Username != "" {
+		t.Errorf("Expected anonymous config for 'tools/estimator', got %+v", cfg)
+	}
+}
+
+func TestLoadActionsConfig_Success(t *testing.T) {
+	os.Setenv("GITHUB_TOKEN", "test_token")
+	os.Setenv("GITHUB_REPOSITORY", "test_owner/test_repo")
+	os.Setenv("GITHUB_EVENT_PATH", "/tmp/event.json")
+	defer func() {
+		os.Unsetenv("GITHUB_TOKEN")
+		os.Unsetenv("GITHUB_REPOSITORY")
+		os.Unsetenv("GITHUB_EVENT_PATH")
+	}()
+
+	cfg, err := config.LoadActionsConfig()
+	if err != nil {
+		t.Fatalf("LoadActionsConfig failed unexpectedly: %v", err)
+	}
+	if cfg.GitHub.Owner != "test_owner" || cfg.GitHub.Repo != "test_repo" {
+		t.Errorf("Expected test_owner/test_repo, got %s/%s", cfg.GitHub.Owner, cfg.GitHub.Repo)
+	}
+	if cfg.EventPath != "/tmp/event.json" {
+		t.Errorf("Expected event path '/tmp/event.json', got '%s'", cfg.EventPath)
+	}
+}
+
+func TestLoadActionsConfig_InvalidRepository(t *testing.T) {
+	os.Setenv("GITHUB_TOKEN", "test_token")
+	os.Setenv("GITHUB_REPOSITORY", "no-slash")
+	defer func() {
+		os.Unsetenv("GITHUB_TOKEN")
+		os.Unsetenv("GITHUB_REPOSITORY")
+	}()
+
+	if _, err := config.LoadActionsConfig(); err == nil {
+		t.Fatal("Expected an error for a malformed GITHUB_REPOSITORY, but got none")
+	}
+}
+
+func TestLoadNotifyConfig(t *testing.T) {
+	os.Unsetenv("NOTIFY_SLACK_WEBHOOK_URL")
+	os.Unsetenv("NOTIFY_TEAMS_WEBHOOK_URL")
+	os.Unsetenv("NOTIFY_WEBHOOK_URL")
+	if _, err := config.LoadNotifyConfig(); err == nil {
+		t.Fatal("Expected an error when no webhook is configured, but got none")
+	}
+
+	os.Setenv("NOTIFY_SLACK_WEBHOOK_URL", "https://hooks.slack.com/services/T/B/X")
+	defer os.Unsetenv("NOTIFY_SLACK_WEBHOOK_URL")
+	cfg, err := config.LoadNotifyConfig()
+	if err != nil {
+		t.Fatalf("LoadNotifyConfig failed unexpectedly: %v", err)
+	}
+	if cfg.SlackWebhookURL != "https://hooks.slack.com/services/T/B/X" || cfg.TeamsWebhookURL != "" {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+}
+
+func TestLoadFeaturesConfig(t *testing.T) {
+	os.Setenv("EXCLUDE_GENERATED_FROM_SIZE", "true")
+	os.Setenv("GENERATED_PATTERNS", "*.gen.ts, api/openapi/*")
+	os.Setenv("GO_COMPLEXITY", "1")
+	defer func() {
+		os.Unsetenv("EXCLUDE_GENERATED_FROM_SIZE")
+		os.Unsetenv("GENERATED_PATTERNS")
+		os.Unsetenv("GO_COMPLEXITY")
+	}()
+
+	cfg, err := config.LoadFeaturesConfig()
+	if err != nil {
+		t.Fatalf("LoadFeaturesConfig failed unexpectedly: %v", err)
+	}
+	if !cfg.ExcludeGenerated || !cfg.GoComplexity || len(cfg.GeneratedPatterns) != 2 || cfg.GeneratedPatterns[1] != "api/openapi/*" {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+
+	os.Setenv("EXCLUDE_GENERATED_FROM_SIZE", "maybe")
+	if _, err := config.LoadFeaturesConfig(); err == nil {
+		t.Fatal("Expected an error for an invalid EXCLUDE_GENERATED_FROM_SIZE, but got none")
+	}
+}
+
+func TestLoadEstimateConfig(t *testing.T) {
+	os.Setenv("ESTIMATE_HALF_LIFE", "720h")
+	os.Setenv("ESTIMATE_WINDOW", "8760h")
+	os.Setenv("ESTIMATE_OUTLIERS", "winsorize")
+	os.Setenv("ESTIMATE_OUTLIER_PERCENTILES", "2.5, 97.5")
+	os.Setenv("ESTIMATE_EXCLUDE", "#12, 345")
+	defer func() {
+		for _, name := range []string{"ESTIMATE_HALF_LIFE", "ESTIMATE_WINDOW", "ESTIMATE_OUTLIERS", "ESTIMATE_OUTLIER_PERCENTILES", "ESTIMATE_EXCLUDE"} {
+			os.Unsetenv(name)
+		}
+	}()
+
+	cfg, err := config.LoadEstimateConfig()
+	if err != nil {
+		t.Fatalf("LoadEstimateConfig failed unexpectedly: %v", err)
+	}
+	if cfg.HalfLife != 720*time.Hour || cfg.Window != 8760*time.Hour || cfg.Outliers != "winsorize" ||
+		cfg.LowerPercentile != 2.5 || cfg.UpperPercentile != 97.5 || len(cfg.Exclude) != 2 || cfg.Exclude[0] != 12 {
+		t.Errorf("Unexpected config %+v", cfg)
+	}
+
+	os.Setenv("ESTIMATE_OUTLIER_PERCENTILES", "95,5")
+	if _, err := config.LoadEstimateConfig(); err == nil {
+		t.Fatal("Expected an error for inverted ESTIMATE_OUTLIER_PERCENTILES, but got none")
+	}
+	os.Setenv("ESTIMATE_WINDOW", "90d")
+	if _, err := config.LoadEstimateConfig(); err == nil {
+		t.Fatal("Expected an error for an invalid ESTIMATE_WINDOW, but got none")
+	}
+}